@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
 )
@@ -39,9 +40,9 @@ type ClusterProfileSpec struct {
 //
 // This field is immutable.
 // It's recommended that each cluster manager instance should set a different values to this field.
-// In addition, it's recommended that a predefined label with key "x-k8s.io/cluster-manager"
-// should be added by the cluster manager upon creation. See constant LabelClusterManagerKey.
-// The value of the label should be the same as the name of the cluster manager.
+// In addition, the cluster manager must add a predefined label with key "x-k8s.io/cluster-manager"
+// upon creation. See constant LabelClusterManagerKey.
+// The value of the label must be the same as the name of the cluster manager.
 // The purpose of this label is to make filter clusters from different cluster managers easier.
 //
 // +kubebuilder:validation:XValidation:rule="self == oldSelf",message="ClusterManager is immutable"
@@ -52,8 +53,12 @@ type ClusterManager struct {
 }
 
 // ClusterProfileStatus defines the observed state of ClusterProfile.
+// +kubebuilder:validation:XValidation:rule="!has(self.accessProviders) || !has(self.credentialProviders) || self.credentialProviders.all(c, !self.accessProviders.exists(a, a.name == c.name))",message="a provider must not be listed in both accessProviders and credentialProviders"
 type ClusterProfileStatus struct {
 	// Conditions contains the different condition statuses for this cluster.
+	// The predefined condition types are ControlPlaneHealthy, Joined, Reachable,
+	// AccessProvidersReady and Deleting. Unknown means the cluster manager could not
+	// determine the status and must not be treated as True.
 	// +optional
 	// +listType=map
 	// +listMapKey=type
@@ -70,9 +75,11 @@ type ClusterProfileStatus struct {
 	// Property names support both:
 	// - Standard names from ClusterProperty resources
 	// - Custom names defined by cluster managers
+	// The list is capped at 4096 entries to bound the cost of validating typed values.
 	// +optional
 	// +listType=map
 	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=4096
 	Properties []Property `json:"properties,omitempty"`
 
 	// CredentialProviders is a list of cluster access providers that can provide access
@@ -83,6 +90,7 @@ type ClusterProfileStatus struct {
 	// +deprecated
 	// +listType=map
 	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=32
 	CredentialProviders []CredentialProvider `json:"credentialProviders,omitempty"`
 
 	// AccessProviders is a list of cluster access providers that can provide access
@@ -90,7 +98,13 @@ type ClusterProfileStatus struct {
 	// +optional
 	// +listType=map
 	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=32
 	AccessProviders []AccessProvider `json:"accessProviders,omitempty"`
+
+	// Resources summarizes the compute resources of the cluster.
+	// Cluster managers should report it instead of encoding capacity in Properties.
+	// +optional
+	Resources *ResourceSummary `json:"resources,omitempty"`
 }
 
 // AccessProvider defines how to access the cluster.
@@ -99,6 +113,7 @@ type ClusterProfileStatus struct {
 // The Cluster field contains the actual cluster connection details, such as server address,
 // certificate authority data, and authentication information.
 type AccessProvider struct {
+	// +kubebuilder:validation:MaxLength=253
 	Name    string              `json:"name"`
 	Cluster clientcmdv1.Cluster `json:"cluster,omitempty"`
 }
@@ -112,11 +127,39 @@ type ClusterVersion struct {
 	Kubernetes string `json:"kubernetes,omitempty"`
 }
 
+// ResourceSummary summarizes the compute resources of the nodes of a cluster.
+type ResourceSummary struct {
+	// Capacity is the total amount of each resource of the nodes counted in NodeCount,
+	// as reported in their status.capacity.
+	// +optional
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+
+	// Allocatable is the total amount of each resource of the nodes counted in NodeCount
+	// that is available for scheduling, as reported in their status.allocatable.
+	// +optional
+	Allocatable corev1.ResourceList `json:"allocatable,omitempty"`
+
+	// NodeCount is the number of nodes included in Capacity and Allocatable.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	NodeCount *int32 `json:"nodeCount,omitempty"`
+
+	// LastObservedTime is the last time the resources were observed on the cluster.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=date-time
+	// +optional
+	LastObservedTime metav1.Time `json:"lastObservedTime,omitempty"`
+}
+
 // Property defines the data structure to represent a property of a cluster.
 // It contains a name/value pair and the last observed time of the property on the cluster.
 // This property can store various configurable details and metrics of a cluster,
 // which may include information such as the entry point of the cluster, types of nodes, location,
 // etc. according to KEP 4322.
+// +kubebuilder:validation:XValidation:rule="!has(self.type) || self.type != 'Integer' || (self.value.matches('^[+-]?[0-9]+$') && (self.value.size() - (self.value.startsWith('+') || self.value.startsWith('-') ? 1 : 0) < 19 || (self.value.size() - (self.value.startsWith('+') || self.value.startsWith('-') ? 1 : 0) == 19 && (self.value.substring(self.value.size() - 19) <= '9223372036854775807' || self.value == '-9223372036854775808'))))",message="value must be a 64-bit integer"
+// +kubebuilder:validation:XValidation:rule="!has(self.type) || self.type != 'Quantity' || isQuantity(self.value)",message="value must be a quantity"
+// +kubebuilder:validation:XValidation:rule="!has(self.type) || self.type != 'Semver' || isSemver(self.value.startsWith('v') ? self.value.substring(1) : self.value)",message="value must be a semantic version"
+// +kubebuilder:validation:XValidation:rule="!has(self.type) || self.type != 'Boolean' || self.value in ['true', 'false']",message="value must be true or false"
 type Property struct {
 	// Name is the name of a property resource on cluster. It's a well-known
 	// or customized name to identify the property.
@@ -131,6 +174,13 @@ type Property struct {
 	// +required
 	Value string `json:"value"`
 
+	// Type is a hint about how to interpret Value. If set, Value must be valid for
+	// the type; JSON values are checked by the validating webhook, the other types
+	// by the CRD schema. Properties without a type are plain strings, as before
+	// the field was introduced.
+	// +optional
+	Type PropertyValueType `json:"type,omitempty"`
+
 	// LastObservedTime is the last time the property was observed on the corresponding cluster.
 	// The value is the timestamp when the property was observed not the time when the property
 	// was updated in the cluster-profile.
@@ -140,19 +190,105 @@ type Property struct {
 	LastObservedTime metav1.Time `json:"lastObservedTime,omitempty"`
 }
 
-// Predefined healthy conditions indicate the cluster is in a good state or not.
+// PropertyValueType is the type of the value of a Property.
+// +kubebuilder:validation:Enum=String;Integer;Quantity;Semver;Boolean;JSON
+type PropertyValueType string
+
+const (
+	// PropertyValueTypeString is a string without further structure.
+	PropertyValueTypeString PropertyValueType = "String"
+	// PropertyValueTypeInteger is a 64-bit signed integer in decimal notation,
+	// with an optional sign and at most 19 digits including leading zeros.
+	PropertyValueTypeInteger PropertyValueType = "Integer"
+	// PropertyValueTypeQuantity is a resource.Quantity, e.g. "80Gi".
+	PropertyValueTypeQuantity PropertyValueType = "Quantity"
+	// PropertyValueTypeSemver is a semantic version, with an optional leading "v".
+	PropertyValueTypeSemver PropertyValueType = "Semver"
+	// PropertyValueTypeBoolean is "true" or "false".
+	PropertyValueTypeBoolean PropertyValueType = "Boolean"
+	// PropertyValueTypeJSON is a JSON document.
+	PropertyValueTypeJSON PropertyValueType = "JSON"
+)
+
+// Predefined conditions describe the lifecycle and health of the cluster.
 // The condition and states conforms to metav1.Condition format.
-// States are True/False/Unknown.
+// States are True/False/Unknown. False means the cluster manager observed the
+// opposite of the condition, while Unknown means it could not determine it,
+// for example because it lost contact with the cluster; consumers must not
+// treat Unknown as True. A missing condition is not reported by the cluster manager.
 const (
 	// ClusterConditionControlPlaneHealthy means the controlplane of the cluster is in a healthy state.
 	// If the control plane is not healthy, then the status condition will be "False".
 	ClusterConditionControlPlaneHealthy string = "ControlPlaneHealthy"
+
+	// ClusterConditionJoined means the cluster has joined the cluster manager and is managed by it.
+	// It is "False" while the cluster is still joining, if joining failed, or once the cluster has left,
+	// and "Unknown" if the cluster manager cannot tell, e.g. before it processed the cluster.
+	ClusterConditionJoined string = "Joined"
+
+	// ClusterConditionReachable means the cluster manager recently reached the API server of the cluster.
+	// It is "False" if the last attempts failed, and "Unknown" if the cluster was not probed yet.
+	// A cluster can be reachable while its control plane is not healthy.
+	ClusterConditionReachable string = "Reachable"
+
+	// ClusterConditionAccessProvidersReady means every access provider in the status is complete and
+	// the cluster manager has verified that it can be used. It is "False" if at least one access provider
+	// is missing or known to be broken, and "Unknown" if the providers were not verified.
+	ClusterConditionAccessProvidersReady string = "AccessProvidersReady"
+
+	// ClusterConditionDeleting means the cluster is being removed from the inventory, and consumers
+	// should stop placing new workloads on it. It is "False" otherwise; a missing condition also
+	// means the cluster is not being deleted.
+	ClusterConditionDeleting string = "Deleting"
+)
+
+// Predefined reasons of the predefined conditions. Cluster managers may use
+// their own reasons, but should use these when they apply.
+const (
+	// ClusterReasonHealthy is used with ControlPlaneHealthy=True.
+	ClusterReasonHealthy = "Healthy"
+	// ClusterReasonUnhealthy is used with ControlPlaneHealthy=False.
+	ClusterReasonUnhealthy = "Unhealthy"
+
+	// ClusterReasonJoined is used with Joined=True.
+	ClusterReasonJoined = "Joined"
+	// ClusterReasonJoining is used with Joined=False while the cluster is joining.
+	ClusterReasonJoining = "Joining"
+	// ClusterReasonJoinFailed is used with Joined=False if the cluster could not join.
+	ClusterReasonJoinFailed = "JoinFailed"
+	// ClusterReasonLeft is used with Joined=False after the cluster has left the cluster manager.
+	ClusterReasonLeft = "Left"
+
+	// ClusterReasonReachable is used with Reachable=True.
+	ClusterReasonReachable = "Reachable"
+	// ClusterReasonUnreachable is used with Reachable=False.
+	ClusterReasonUnreachable = "Unreachable"
+
+	// ClusterReasonAccessProvidersReady is used with AccessProvidersReady=True.
+	ClusterReasonAccessProvidersReady = "AccessProvidersReady"
+	// ClusterReasonAccessProviderMissing is used with AccessProvidersReady=False if an expected
+	// access provider is not in the status.
+	ClusterReasonAccessProviderMissing = "AccessProviderMissing"
+	// ClusterReasonAccessProviderInvalid is used with AccessProvidersReady=False if an access
+	// provider is in the status but cannot be used, e.g. because its credentials are rejected.
+	ClusterReasonAccessProviderInvalid = "AccessProviderInvalid"
+
+	// ClusterReasonDeletionRequested is used with Deleting=True.
+	ClusterReasonDeletionRequested = "DeletionRequested"
+	// ClusterReasonNotDeleting is used with Deleting=False.
+	ClusterReasonNotDeleting = "NotDeleting"
+
+	// ClusterReasonStatusUnknown is used with any predefined condition whose status is "Unknown"
+	// because the cluster manager lost contact with the cluster or has not checked it yet.
+	ClusterReasonStatusUnknown = "StatusUnknown"
 )
 
 const (
 	// LabelClusterManagerKey is used to indicate the name of the cluster manager that a ClusterProfile belongs to.
 	// The value of the label MUST be the same as the name of the cluster manager.
 	// The purpose of this label is to make filter clusters from different cluster managers easier.
+	// CRD validation rules cannot read labels; the ValidatingAdmissionPolicy in config/policy
+	// enforces it instead.
 	LabelClusterManagerKey = "x-k8s.io/cluster-manager"
 
 	// LabelClusterSetKey is used on a namespace to indicate the clusterset that a ClusterProfile belongs to.
@@ -165,8 +301,11 @@ const (
 
 //+genclient
 //+kubebuilder:object:root=true
+//+kubebuilder:storageversion
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:scope=Namespaced
+//+kubebuilder:selectablefield:JSONPath=".spec.clusterManager.name"
+//+kubebuilder:selectablefield:JSONPath=".spec.displayName"
 
 // ClusterProfile represents a single cluster in a multi-cluster deployment.
 type ClusterProfile struct {
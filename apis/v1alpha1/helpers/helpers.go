@@ -0,0 +1,161 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package helpers contains functions to read and update the status of
+// v1alpha1 ClusterProfiles consistently across cluster managers and consumers.
+//
+// Lists keyed by +listMapKey are treated as maps: setting an entry replaces
+// the entry with the same key in place, or appends it if there is none.
+package helpers
+
+import (
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+)
+
+// SetCondition sets condition in the status of profile and reports whether
+// the status changed.
+//
+// LastTransitionTime only changes when the condition status changes; if it is
+// unset on a new or transitioning condition, the current time is used.
+// ObservedGeneration defaults to the generation of profile.
+func SetCondition(profile *v1alpha1.ClusterProfile, condition metav1.Condition) bool {
+	if condition.ObservedGeneration == 0 {
+		condition.ObservedGeneration = profile.Generation
+	}
+	return meta.SetStatusCondition(&profile.Status.Conditions, condition)
+}
+
+// GetCondition returns the condition of conditionType, or nil if it is not set.
+func GetCondition(profile *v1alpha1.ClusterProfile, conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(profile.Status.Conditions, conditionType)
+}
+
+// RemoveCondition removes the condition of conditionType and reports whether
+// it was set.
+func RemoveCondition(profile *v1alpha1.ClusterProfile, conditionType string) bool {
+	return meta.RemoveStatusCondition(&profile.Status.Conditions, conditionType)
+}
+
+// IsControlPlaneHealthy reports whether the ControlPlaneHealthy condition of
+// profile is True. A missing condition is treated as not healthy.
+func IsControlPlaneHealthy(profile *v1alpha1.ClusterProfile) bool {
+	return meta.IsStatusConditionTrue(profile.Status.Conditions, v1alpha1.ClusterConditionControlPlaneHealthy)
+}
+
+// IsJoined reports whether the Joined condition of profile is True.
+func IsJoined(profile *v1alpha1.ClusterProfile) bool {
+	return meta.IsStatusConditionTrue(profile.Status.Conditions, v1alpha1.ClusterConditionJoined)
+}
+
+// IsReachable reports whether the Reachable condition of profile is True.
+func IsReachable(profile *v1alpha1.ClusterProfile) bool {
+	return meta.IsStatusConditionTrue(profile.Status.Conditions, v1alpha1.ClusterConditionReachable)
+}
+
+// AreAccessProvidersReady reports whether the AccessProvidersReady condition
+// of profile is True.
+func AreAccessProvidersReady(profile *v1alpha1.ClusterProfile) bool {
+	return meta.IsStatusConditionTrue(profile.Status.Conditions, v1alpha1.ClusterConditionAccessProvidersReady)
+}
+
+// IsDeleting reports whether profile is being removed from the inventory,
+// either because its Deleting condition is True or because the ClusterProfile
+// itself has a deletion timestamp. Unknown is treated as not deleting.
+func IsDeleting(profile *v1alpha1.ClusterProfile) bool {
+	return profile.DeletionTimestamp != nil ||
+		meta.IsStatusConditionTrue(profile.Status.Conditions, v1alpha1.ClusterConditionDeleting)
+}
+
+// GetProperty returns the property name, or nil if it is not set.
+func GetProperty(profile *v1alpha1.ClusterProfile, name string) *v1alpha1.Property {
+	for i := range profile.Status.Properties {
+		if profile.Status.Properties[i].Name == name {
+			return &profile.Status.Properties[i]
+		}
+	}
+	return nil
+}
+
+// SetProperty sets property in the status of profile with
+// ClusterProfileStatus.SetTypedProperty, which validates it and removes it if
+// its value is empty, and reports whether its value or type changed.
+// LastObservedTime is always updated, and defaults to the current time, because
+// re-observing an unchanged value is still an observation.
+func SetProperty(profile *v1alpha1.ClusterProfile, property v1alpha1.Property) (bool, error) {
+	if property.LastObservedTime.IsZero() {
+		property.LastObservedTime = metav1.Now()
+	}
+	var old *v1alpha1.Property
+	if existing := GetProperty(profile, property.Name); existing != nil {
+		old = existing.DeepCopy()
+	}
+	err := profile.Status.SetTypedProperty(property.Name, property.Type, property.Value, property.LastObservedTime)
+	if err != nil {
+		return false, err
+	}
+	updated := GetProperty(profile, property.Name)
+	if old == nil || updated == nil {
+		return (old == nil) != (updated == nil), nil
+	}
+	return old.Value != updated.Value || old.Type != updated.Type, nil
+}
+
+// RemoveProperty removes the property name and reports whether it was set.
+func RemoveProperty(profile *v1alpha1.ClusterProfile, name string) bool {
+	n := len(profile.Status.Properties)
+	profile.Status.Properties = slices.DeleteFunc(profile.Status.Properties, func(p v1alpha1.Property) bool {
+		return p.Name == name
+	})
+	return len(profile.Status.Properties) != n
+}
+
+// EffectiveAccessProviders returns the access providers of profile: all
+// AccessProviders, followed by the deprecated CredentialProviders whose name is
+// not used by an AccessProvider. The slice is new, but the providers are shallow
+// copies that share their extensions and CA data with profile.
+func EffectiveAccessProviders(profile *v1alpha1.ClusterProfile) []v1alpha1.AccessProvider {
+	providers := slices.Clone(profile.Status.AccessProviders)
+	for _, p := range profile.Status.CredentialProviders {
+		if !slices.ContainsFunc(profile.Status.AccessProviders, func(a v1alpha1.AccessProvider) bool {
+			return a.Name == p.Name
+		}) {
+			providers = append(providers, p)
+		}
+	}
+	return providers
+}
+
+// FindAccessProvider returns the access provider name of profile, preferring
+// AccessProviders over the deprecated CredentialProviders, or nil if neither
+// has it. The returned provider points into profile.
+func FindAccessProvider(profile *v1alpha1.ClusterProfile, name string) *v1alpha1.AccessProvider {
+	for i := range profile.Status.AccessProviders {
+		if profile.Status.AccessProviders[i].Name == name {
+			return &profile.Status.AccessProviders[i]
+		}
+	}
+	for i := range profile.Status.CredentialProviders {
+		if profile.Status.CredentialProviders[i].Name == name {
+			return &profile.Status.CredentialProviders[i]
+		}
+	}
+	return nil
+}
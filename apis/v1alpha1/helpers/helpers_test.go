@@ -0,0 +1,255 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+
+	"sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+)
+
+func TestHelpers(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "v1alpha1 Helpers Suite")
+}
+
+var _ = ginkgo.Describe("Conditions", func() {
+	var profile *v1alpha1.ClusterProfile
+	earlier := metav1.NewTime(time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(earlier.Add(time.Hour))
+
+	ginkgo.BeforeEach(func() {
+		profile = &v1alpha1.ClusterProfile{ObjectMeta: metav1.ObjectMeta{Generation: 3}}
+	})
+
+	ginkgo.It("should add a condition and default its observed generation", func() {
+		changed := SetCondition(profile, metav1.Condition{
+			Type:               v1alpha1.ClusterConditionControlPlaneHealthy,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Healthy",
+			LastTransitionTime: earlier,
+		})
+		gomega.Expect(changed).To(gomega.BeTrue())
+		c := GetCondition(profile, v1alpha1.ClusterConditionControlPlaneHealthy)
+		gomega.Expect(c).NotTo(gomega.BeNil())
+		gomega.Expect(c.ObservedGeneration).To(gomega.Equal(int64(3)))
+		gomega.Expect(c.LastTransitionTime).To(gomega.Equal(earlier))
+		gomega.Expect(IsControlPlaneHealthy(profile)).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("should keep the transition time while the status does not change", func() {
+		SetCondition(profile, metav1.Condition{
+			Type: v1alpha1.ClusterConditionControlPlaneHealthy, Status: metav1.ConditionTrue,
+			Reason: "Healthy", LastTransitionTime: earlier,
+		})
+		changed := SetCondition(profile, metav1.Condition{
+			Type: v1alpha1.ClusterConditionControlPlaneHealthy, Status: metav1.ConditionTrue,
+			Reason: "StillHealthy", Message: "probe succeeded", LastTransitionTime: later,
+		})
+		gomega.Expect(changed).To(gomega.BeTrue())
+		c := GetCondition(profile, v1alpha1.ClusterConditionControlPlaneHealthy)
+		gomega.Expect(c.Reason).To(gomega.Equal("StillHealthy"))
+		gomega.Expect(c.LastTransitionTime).To(gomega.Equal(earlier))
+		gomega.Expect(profile.Status.Conditions).To(gomega.HaveLen(1))
+
+		changed = SetCondition(profile, metav1.Condition{
+			Type: v1alpha1.ClusterConditionControlPlaneHealthy, Status: metav1.ConditionTrue,
+			Reason: "StillHealthy", Message: "probe succeeded",
+		})
+		gomega.Expect(changed).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should update the transition time when the status changes", func() {
+		SetCondition(profile, metav1.Condition{
+			Type: v1alpha1.ClusterConditionControlPlaneHealthy, Status: metav1.ConditionTrue,
+			Reason: "Healthy", LastTransitionTime: earlier,
+		})
+		SetCondition(profile, metav1.Condition{
+			Type: v1alpha1.ClusterConditionControlPlaneHealthy, Status: metav1.ConditionFalse,
+			Reason: "Unreachable", LastTransitionTime: later,
+		})
+		c := GetCondition(profile, v1alpha1.ClusterConditionControlPlaneHealthy)
+		gomega.Expect(c.LastTransitionTime).To(gomega.Equal(later))
+		gomega.Expect(IsControlPlaneHealthy(profile)).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should default the transition time to now", func() {
+		before := time.Now().Add(-time.Second)
+		SetCondition(profile, metav1.Condition{
+			Type: v1alpha1.ClusterConditionControlPlaneHealthy, Status: metav1.ConditionUnknown, Reason: "Probing",
+		})
+		c := GetCondition(profile, v1alpha1.ClusterConditionControlPlaneHealthy)
+		gomega.Expect(c.LastTransitionTime.Time).To(gomega.BeTemporally(">", before))
+	})
+
+	ginkgo.It("should treat a missing or unknown condition as not healthy", func() {
+		gomega.Expect(IsControlPlaneHealthy(profile)).To(gomega.BeFalse())
+		SetCondition(profile, metav1.Condition{
+			Type: v1alpha1.ClusterConditionControlPlaneHealthy, Status: metav1.ConditionUnknown, Reason: "Probing",
+		})
+		gomega.Expect(IsControlPlaneHealthy(profile)).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should remove a condition", func() {
+		gomega.Expect(RemoveCondition(profile, v1alpha1.ClusterConditionControlPlaneHealthy)).To(gomega.BeFalse())
+		SetCondition(profile, metav1.Condition{
+			Type: v1alpha1.ClusterConditionControlPlaneHealthy, Status: metav1.ConditionTrue, Reason: "Healthy",
+		})
+		gomega.Expect(RemoveCondition(profile, v1alpha1.ClusterConditionControlPlaneHealthy)).To(gomega.BeTrue())
+		gomega.Expect(GetCondition(profile, v1alpha1.ClusterConditionControlPlaneHealthy)).To(gomega.BeNil())
+	})
+})
+
+var _ = ginkgo.Describe("Lifecycle conditions", func() {
+	ginkgo.It("should only report conditions that are True", func() {
+		profile := &v1alpha1.ClusterProfile{}
+		gomega.Expect(IsJoined(profile)).To(gomega.BeFalse())
+		gomega.Expect(IsReachable(profile)).To(gomega.BeFalse())
+		gomega.Expect(AreAccessProvidersReady(profile)).To(gomega.BeFalse())
+		gomega.Expect(IsDeleting(profile)).To(gomega.BeFalse())
+
+		SetCondition(profile, metav1.Condition{
+			Type: v1alpha1.ClusterConditionJoined, Status: metav1.ConditionTrue, Reason: v1alpha1.ClusterReasonJoined,
+		})
+		SetCondition(profile, metav1.Condition{
+			Type: v1alpha1.ClusterConditionReachable, Status: metav1.ConditionUnknown,
+			Reason: v1alpha1.ClusterReasonStatusUnknown,
+		})
+		SetCondition(profile, metav1.Condition{
+			Type: v1alpha1.ClusterConditionAccessProvidersReady, Status: metav1.ConditionFalse,
+			Reason: v1alpha1.ClusterReasonAccessProviderInvalid,
+		})
+		SetCondition(profile, metav1.Condition{
+			Type: v1alpha1.ClusterConditionDeleting, Status: metav1.ConditionFalse,
+			Reason: v1alpha1.ClusterReasonNotDeleting,
+		})
+		gomega.Expect(IsJoined(profile)).To(gomega.BeTrue())
+		gomega.Expect(IsReachable(profile)).To(gomega.BeFalse())
+		gomega.Expect(AreAccessProvidersReady(profile)).To(gomega.BeFalse())
+		gomega.Expect(IsDeleting(profile)).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should treat a ClusterProfile with a deletion timestamp as deleting", func() {
+		now := metav1.Now()
+		profile := &v1alpha1.ClusterProfile{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &now}}
+		gomega.Expect(IsDeleting(profile)).To(gomega.BeTrue())
+
+		profile = &v1alpha1.ClusterProfile{}
+		SetCondition(profile, metav1.Condition{
+			Type: v1alpha1.ClusterConditionDeleting, Status: metav1.ConditionTrue,
+			Reason: v1alpha1.ClusterReasonDeletionRequested,
+		})
+		gomega.Expect(IsDeleting(profile)).To(gomega.BeTrue())
+	})
+})
+
+var _ = ginkgo.Describe("Properties", func() {
+	var profile *v1alpha1.ClusterProfile
+	observed := metav1.NewTime(time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC))
+
+	ginkgo.BeforeEach(func() {
+		profile = &v1alpha1.ClusterProfile{}
+	})
+
+	ginkgo.It("should add, replace and remove properties by name", func() {
+		gomega.Expect(SetProperty(profile, v1alpha1.Property{Name: "a", Value: "1", LastObservedTime: observed})).To(gomega.BeTrue())
+		gomega.Expect(SetProperty(profile, v1alpha1.Property{Name: "b", Value: "2", LastObservedTime: observed})).To(gomega.BeTrue())
+		gomega.Expect(SetProperty(profile, v1alpha1.Property{Name: "a", Value: "3", LastObservedTime: observed})).To(gomega.BeTrue())
+
+		gomega.Expect(profile.Status.Properties).To(gomega.HaveLen(2))
+		gomega.Expect(profile.Status.Properties[0].Name).To(gomega.Equal("a"))
+		gomega.Expect(GetProperty(profile, "a").Value).To(gomega.Equal("3"))
+
+		gomega.Expect(RemoveProperty(profile, "a")).To(gomega.BeTrue())
+		gomega.Expect(RemoveProperty(profile, "a")).To(gomega.BeFalse())
+		gomega.Expect(GetProperty(profile, "a")).To(gomega.BeNil())
+		gomega.Expect(profile.Status.Properties).To(gomega.HaveLen(1))
+	})
+
+	ginkgo.It("should remove properties set to an empty value", func() {
+		gomega.Expect(SetProperty(profile, v1alpha1.Property{Name: "a", Value: "1"})).To(gomega.BeTrue())
+		gomega.Expect(SetProperty(profile, v1alpha1.Property{Name: "a"})).To(gomega.BeTrue())
+		gomega.Expect(GetProperty(profile, "a")).To(gomega.BeNil())
+		gomega.Expect(SetProperty(profile, v1alpha1.Property{Name: "a"})).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should reject invalid values like the status setters", func() {
+		changed, err := SetProperty(profile, v1alpha1.Property{Name: v1alpha1.PropertyRegion, Value: "not a label value"})
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("invalid value")))
+		gomega.Expect(changed).To(gomega.BeFalse())
+
+		_, err = SetProperty(profile, v1alpha1.Property{
+			Name: "example.com/gpus", Value: "eight", Type: v1alpha1.PropertyValueTypeInteger,
+		})
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("must be a 64-bit integer")))
+		gomega.Expect(profile.Status.Properties).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should refresh the observation time of an unchanged value", func() {
+		gomega.Expect(SetProperty(profile, v1alpha1.Property{Name: "a", Value: "1", LastObservedTime: observed})).To(gomega.BeTrue())
+		later := metav1.NewTime(observed.Add(time.Minute))
+		gomega.Expect(SetProperty(profile, v1alpha1.Property{Name: "a", Value: "1", LastObservedTime: later})).To(gomega.BeFalse())
+		gomega.Expect(GetProperty(profile, "a").LastObservedTime).To(gomega.Equal(later))
+	})
+
+	ginkgo.It("should default the observation time to now", func() {
+		before := time.Now().Add(-time.Second)
+		gomega.Expect(SetProperty(profile, v1alpha1.Property{Name: "a", Value: "1"})).To(gomega.BeTrue())
+		gomega.Expect(GetProperty(profile, "a").LastObservedTime.Time).To(gomega.BeTemporally(">", before))
+	})
+})
+
+var _ = ginkgo.Describe("Access providers", func() {
+	profile := &v1alpha1.ClusterProfile{
+		Status: v1alpha1.ClusterProfileStatus{
+			AccessProviders: []v1alpha1.AccessProvider{
+				{Name: "token", Cluster: clientcmdv1.Cluster{Server: "https://new"}},
+				{Name: "exec", Cluster: clientcmdv1.Cluster{Server: "https://exec"}},
+			},
+			CredentialProviders: []v1alpha1.AccessProvider{
+				{Name: "token", Cluster: clientcmdv1.Cluster{Server: "https://old"}},
+				{Name: "legacy", Cluster: clientcmdv1.Cluster{Server: "https://legacy"}},
+			},
+		},
+	}
+
+	ginkgo.It("should list access providers before credential providers without duplicates", func() {
+		providers := EffectiveAccessProviders(profile)
+		gomega.Expect(providers).To(gomega.HaveLen(3))
+		gomega.Expect([]string{providers[0].Name, providers[1].Name, providers[2].Name}).
+			To(gomega.Equal([]string{"token", "exec", "legacy"}))
+		gomega.Expect(providers[0].Cluster.Server).To(gomega.Equal("https://new"))
+	})
+
+	ginkgo.It("should prefer access providers when finding a provider", func() {
+		gomega.Expect(FindAccessProvider(profile, "token").Cluster.Server).To(gomega.Equal("https://new"))
+		gomega.Expect(FindAccessProvider(profile, "legacy").Cluster.Server).To(gomega.Equal("https://legacy"))
+		gomega.Expect(FindAccessProvider(profile, "missing")).To(gomega.BeNil())
+	})
+
+	ginkgo.It("should handle a profile without providers", func() {
+		empty := &v1alpha1.ClusterProfile{}
+		gomega.Expect(EffectiveAccessProviders(empty)).To(gomega.BeEmpty())
+		gomega.Expect(FindAccessProvider(empty, "token")).To(gomega.BeNil())
+	})
+})
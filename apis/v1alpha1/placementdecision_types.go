@@ -66,7 +66,9 @@ type ClusterDecision struct {
 
 //+genclient
 //+kubebuilder:object:root=true
+//+kubebuilder:storageversion
 //+kubebuilder:resource:scope=Namespaced
+//+kubebuilder:selectablefield:JSONPath=".schedulerName"
 
 // PlacementDecision publishes the set of clusters chosen by a scheduler at a point in time.
 // It is a data-only resource that acts as the interface between schedulers and consumers.
@@ -0,0 +1,409 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// Well-known property names. Cluster managers should report these properties
+// under these names, so that consumers can read them without knowing the
+// cluster manager. Use the typed accessors on ClusterProfileStatus to read and
+// write them; setting an empty value or list removes the property.
+const (
+	// PropertyClusterID is the ID of the cluster, unique within its ClusterSet.
+	// It mirrors the cluster.clusterset.k8s.io ClusterProperty defined in KEP-2149.
+	// The value is a DNS subdomain.
+	PropertyClusterID = "cluster.clusterset.k8s.io"
+
+	// PropertyClusterSet is the ClusterSet the cluster belongs to.
+	// It mirrors the clusterset.k8s.io ClusterProperty defined in KEP-2149.
+	// The value is a DNS subdomain.
+	PropertyClusterSet = "clusterset.k8s.io"
+
+	// PropertyRegion is the cloud region of the cluster, e.g. "us-east-1".
+	// The value is a label value, like the node label of the same name.
+	PropertyRegion = "topology.kubernetes.io/region"
+
+	// PropertyZones is the comma-separated, sorted list of zones the nodes of the
+	// cluster run in, e.g. "us-east-1a,us-east-1b".
+	// Each zone is a label value, like the node label of the same name.
+	PropertyZones = "topology.kubernetes.io/zone"
+
+	// PropertyCloudProvider is the infrastructure provider of the cluster,
+	// e.g. "aws", "azure", "gcp" or "on-prem". The value is a label value.
+	PropertyCloudProvider = "multicluster.x-k8s.io/cloud-provider"
+
+	// PropertyDistribution is the Kubernetes distribution of the cluster,
+	// e.g. "eks", "aks", "gke", "openshift" or "k3s". The value is a label value.
+	PropertyDistribution = "multicluster.x-k8s.io/distribution"
+
+	// PropertyDistributionVersion is the version of the distribution.
+	// The value is a semantic version, e.g. "4.15.2".
+	PropertyDistributionVersion = "multicluster.x-k8s.io/distribution-version"
+
+	// PropertyNodeArchitectures is the comma-separated, sorted list of CPU
+	// architectures of the nodes of the cluster, e.g. "amd64,arm64".
+	// Each architecture is a value of the kubernetes.io/arch node label.
+	PropertyNodeArchitectures = "kubernetes.io/arch"
+)
+
+// maxPropertyValueLength is the maximum length of Property.Value.
+const maxPropertyValueLength = 1024
+
+// wellKnownPropertyValidators validate the values of well-known properties.
+var wellKnownPropertyValidators = map[string]func(string) []string{
+	PropertyClusterID:           validation.IsDNS1123Subdomain,
+	PropertyClusterSet:          validation.IsDNS1123Subdomain,
+	PropertyRegion:              validation.IsValidLabelValue,
+	PropertyZones:               validateLabelValueList,
+	PropertyCloudProvider:       validation.IsValidLabelValue,
+	PropertyDistribution:        validation.IsValidLabelValue,
+	PropertyDistributionVersion: validateSemver,
+	PropertyNodeArchitectures:   validateLabelValueList,
+}
+
+// ValidateWellKnownProperty returns the reasons why value is invalid for the
+// well-known property name, if any. Properties that are not well-known are
+// always valid.
+func ValidateWellKnownProperty(name, value string) []string {
+	if validate, ok := wellKnownPropertyValidators[name]; ok {
+		return validate(value)
+	}
+	return nil
+}
+
+// ValidatePropertyValue returns the reasons why value is invalid for
+// valueType, if any. Any value is valid for an untyped or String property.
+func ValidatePropertyValue(valueType PropertyValueType, value string) []string {
+	switch valueType {
+	case "", PropertyValueTypeString:
+	case PropertyValueTypeInteger:
+		// The CRD rule cannot strip leading zeros, so it limits the digits
+		// instead of the value; this check does the same.
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil || len(strings.TrimLeft(value, "+-")) > 19 {
+			return []string{"must be a 64-bit integer"}
+		}
+	case PropertyValueTypeQuantity:
+		if _, err := resource.ParseQuantity(value); err != nil {
+			return []string{err.Error()}
+		}
+	case PropertyValueTypeSemver:
+		return validateSemver(value)
+	case PropertyValueTypeBoolean:
+		if value != "true" && value != "false" {
+			return []string{"must be true or false"}
+		}
+	case PropertyValueTypeJSON:
+		if !json.Valid([]byte(value)) {
+			return []string{"must be valid JSON"}
+		}
+	default:
+		return []string{fmt.Sprintf("unknown value type %q", valueType)}
+	}
+	return nil
+}
+
+// ValueType returns the type of the value of p. Untyped properties are strings.
+func (p *Property) ValueType() PropertyValueType {
+	if p.Type == "" {
+		return PropertyValueTypeString
+	}
+	return p.Type
+}
+
+func validateLabelValueList(value string) []string {
+	var errs []string
+	for _, v := range strings.Split(value, ",") {
+		if v == "" {
+			errs = append(errs, "must not contain empty list entries")
+			continue
+		}
+		errs = append(errs, validation.IsValidLabelValue(v)...)
+	}
+	return errs
+}
+
+func validateSemver(value string) []string {
+	if _, err := version.ParseSemantic(value); err != nil {
+		return []string{err.Error()}
+	}
+	return nil
+}
+
+// property returns the property name, or nil if it is not set.
+func (s *ClusterProfileStatus) property(name string) *Property {
+	for i := range s.Properties {
+		if s.Properties[i].Name == name {
+			return &s.Properties[i]
+		}
+	}
+	return nil
+}
+
+// propertyValue returns the value of the property name, or "" if it is not set.
+func (s *ClusterProfileStatus) propertyValue(name string) string {
+	if p := s.property(name); p != nil {
+		return p.Value
+	}
+	return ""
+}
+
+// typedProperty returns the property name, or nil if it is not set. It fails if
+// the property declares a type other than valueType; untyped properties match
+// any type, as they did before types were introduced.
+func (s *ClusterProfileStatus) typedProperty(name string, valueType PropertyValueType) (*Property, error) {
+	p := s.property(name)
+	if p == nil || p.Type == "" || p.Type == valueType {
+		return p, nil
+	}
+	return nil, fmt.Errorf("property %s has type %s, not %s", name, p.Type, valueType)
+}
+
+// setProperty validates value and sets the untyped property name to it,
+// observed at observedTime. An empty value removes the property.
+func (s *ClusterProfileStatus) setProperty(name, value string, observedTime metav1.Time) error {
+	return s.SetTypedProperty(name, "", value, observedTime)
+}
+
+// SetTypedProperty validates value against valueType and, for well-known
+// properties, against the property, and sets the property name to it, observed
+// at observedTime. An empty value removes the property. Prefer the setters of
+// well-known properties and of a specific type, which format the value.
+func (s *ClusterProfileStatus) SetTypedProperty(name string, valueType PropertyValueType, value string, observedTime metav1.Time) error {
+	if value == "" {
+		s.Properties = slices.DeleteFunc(s.Properties, func(p Property) bool { return p.Name == name })
+		return nil
+	}
+	if len(value) > maxPropertyValueLength {
+		return fmt.Errorf("invalid value for property %s: must be no more than %d characters", name, maxPropertyValueLength)
+	}
+	if errs := ValidateWellKnownProperty(name, value); len(errs) > 0 {
+		return fmt.Errorf("invalid value %q for property %s: %s", value, name, strings.Join(errs, "; "))
+	}
+	if errs := ValidatePropertyValue(valueType, value); len(errs) > 0 {
+		return fmt.Errorf("invalid %s value %q for property %s: %s", valueType, value, name, strings.Join(errs, "; "))
+	}
+	if p := s.property(name); p != nil {
+		p.Value = value
+		p.Type = valueType
+		p.LastObservedTime = observedTime
+		return nil
+	}
+	s.Properties = append(s.Properties, Property{Name: name, Value: value, Type: valueType, LastObservedTime: observedTime})
+	return nil
+}
+
+// setListProperty sets the property name to the sorted, de-duplicated values.
+func (s *ClusterProfileStatus) setListProperty(name string, values []string, observedTime metav1.Time) error {
+	values = slices.Clone(values)
+	slices.Sort(values)
+	return s.setProperty(name, strings.Join(slices.Compact(values), ","), observedTime)
+}
+
+// listPropertyValue returns the entries of the comma-separated property name.
+func (s *ClusterProfileStatus) listPropertyValue(name string) []string {
+	value := s.propertyValue(name)
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// ClusterID returns the PropertyClusterID property, or "" if it is not set.
+func (s *ClusterProfileStatus) ClusterID() string { return s.propertyValue(PropertyClusterID) }
+
+// SetClusterID sets the PropertyClusterID property.
+func (s *ClusterProfileStatus) SetClusterID(id string, observedTime metav1.Time) error {
+	return s.setProperty(PropertyClusterID, id, observedTime)
+}
+
+// ClusterSet returns the PropertyClusterSet property, or "" if it is not set.
+func (s *ClusterProfileStatus) ClusterSet() string { return s.propertyValue(PropertyClusterSet) }
+
+// SetClusterSet sets the PropertyClusterSet property.
+func (s *ClusterProfileStatus) SetClusterSet(clusterSet string, observedTime metav1.Time) error {
+	return s.setProperty(PropertyClusterSet, clusterSet, observedTime)
+}
+
+// Region returns the PropertyRegion property, or "" if it is not set.
+func (s *ClusterProfileStatus) Region() string { return s.propertyValue(PropertyRegion) }
+
+// SetRegion sets the PropertyRegion property.
+func (s *ClusterProfileStatus) SetRegion(region string, observedTime metav1.Time) error {
+	return s.setProperty(PropertyRegion, region, observedTime)
+}
+
+// Zones returns the entries of the PropertyZones property.
+func (s *ClusterProfileStatus) Zones() []string { return s.listPropertyValue(PropertyZones) }
+
+// SetZones sets the PropertyZones property to the sorted, de-duplicated zones.
+func (s *ClusterProfileStatus) SetZones(zones []string, observedTime metav1.Time) error {
+	return s.setListProperty(PropertyZones, zones, observedTime)
+}
+
+// CloudProvider returns the PropertyCloudProvider property, or "" if it is not set.
+func (s *ClusterProfileStatus) CloudProvider() string { return s.propertyValue(PropertyCloudProvider) }
+
+// SetCloudProvider sets the PropertyCloudProvider property.
+func (s *ClusterProfileStatus) SetCloudProvider(provider string, observedTime metav1.Time) error {
+	return s.setProperty(PropertyCloudProvider, provider, observedTime)
+}
+
+// Distribution returns the PropertyDistribution property, or "" if it is not set.
+func (s *ClusterProfileStatus) Distribution() string { return s.propertyValue(PropertyDistribution) }
+
+// SetDistribution sets the PropertyDistribution property.
+func (s *ClusterProfileStatus) SetDistribution(distribution string, observedTime metav1.Time) error {
+	return s.setProperty(PropertyDistribution, distribution, observedTime)
+}
+
+// DistributionVersion returns the PropertyDistributionVersion property, or
+// nil if it is not set.
+func (s *ClusterProfileStatus) DistributionVersion() (*version.Version, error) {
+	return s.SemverProperty(PropertyDistributionVersion)
+}
+
+// SetDistributionVersion sets the PropertyDistributionVersion property.
+func (s *ClusterProfileStatus) SetDistributionVersion(v *version.Version, observedTime metav1.Time) error {
+	return s.SetSemverProperty(PropertyDistributionVersion, v, observedTime)
+}
+
+// NodeArchitectures returns the entries of the PropertyNodeArchitectures property.
+func (s *ClusterProfileStatus) NodeArchitectures() []string {
+	return s.listPropertyValue(PropertyNodeArchitectures)
+}
+
+// SetNodeArchitectures sets the PropertyNodeArchitectures property to the
+// sorted, de-duplicated architectures.
+func (s *ClusterProfileStatus) SetNodeArchitectures(architectures []string, observedTime metav1.Time) error {
+	return s.setListProperty(PropertyNodeArchitectures, architectures, observedTime)
+}
+
+// QuantityProperty parses the property name as a resource.Quantity. It
+// returns nil if the property is not set, and an error if it has another type.
+func (s *ClusterProfileStatus) QuantityProperty(name string) (*resource.Quantity, error) {
+	p, err := s.typedProperty(name, PropertyValueTypeQuantity)
+	if p == nil {
+		return nil, err
+	}
+	q, err := resource.ParseQuantity(p.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quantity %q in property %s: %w", p.Value, name, err)
+	}
+	return &q, nil
+}
+
+// SetQuantityProperty sets the property name to the canonical form of q, with
+// type Quantity.
+func (s *ClusterProfileStatus) SetQuantityProperty(name string, q resource.Quantity, observedTime metav1.Time) error {
+	return s.SetTypedProperty(name, PropertyValueTypeQuantity, q.String(), observedTime)
+}
+
+// SemverProperty parses the property name as a semantic version, with an
+// optional leading "v". It returns nil if the property is not set, and an
+// error if it has another type.
+func (s *ClusterProfileStatus) SemverProperty(name string) (*version.Version, error) {
+	p, err := s.typedProperty(name, PropertyValueTypeSemver)
+	if p == nil {
+		return nil, err
+	}
+	v, err := version.ParseSemantic(p.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid semantic version %q in property %s: %w", p.Value, name, err)
+	}
+	return v, nil
+}
+
+// SetSemverProperty sets the property name to v, without a leading "v", with
+// type Semver.
+func (s *ClusterProfileStatus) SetSemverProperty(name string, v *version.Version, observedTime metav1.Time) error {
+	if v == nil {
+		return fmt.Errorf("missing version for property %s", name)
+	}
+	return s.SetTypedProperty(name, PropertyValueTypeSemver, v.String(), observedTime)
+}
+
+// IntegerProperty parses the property name as a 64-bit integer. It returns nil
+// if the property is not set, and an error if it has another type.
+func (s *ClusterProfileStatus) IntegerProperty(name string) (*int64, error) {
+	p, err := s.typedProperty(name, PropertyValueTypeInteger)
+	if p == nil {
+		return nil, err
+	}
+	i, err := strconv.ParseInt(p.Value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid integer %q in property %s: %w", p.Value, name, err)
+	}
+	return &i, nil
+}
+
+// SetIntegerProperty sets the property name to i, with type Integer.
+func (s *ClusterProfileStatus) SetIntegerProperty(name string, i int64, observedTime metav1.Time) error {
+	return s.SetTypedProperty(name, PropertyValueTypeInteger, strconv.FormatInt(i, 10), observedTime)
+}
+
+// BooleanProperty parses the property name as "true" or "false". It returns
+// nil if the property is not set, and an error if it has another type.
+func (s *ClusterProfileStatus) BooleanProperty(name string) (*bool, error) {
+	p, err := s.typedProperty(name, PropertyValueTypeBoolean)
+	if p == nil {
+		return nil, err
+	}
+	if errs := ValidatePropertyValue(PropertyValueTypeBoolean, p.Value); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid boolean %q in property %s", p.Value, name)
+	}
+	b := p.Value == "true"
+	return &b, nil
+}
+
+// SetBooleanProperty sets the property name to b, with type Boolean.
+func (s *ClusterProfileStatus) SetBooleanProperty(name string, b bool, observedTime metav1.Time) error {
+	return s.SetTypedProperty(name, PropertyValueTypeBoolean, strconv.FormatBool(b), observedTime)
+}
+
+// JSONProperty unmarshals the property name into v, and reports whether the
+// property is set. It fails if the property has another type.
+func (s *ClusterProfileStatus) JSONProperty(name string, v any) (bool, error) {
+	p, err := s.typedProperty(name, PropertyValueTypeJSON)
+	if p == nil {
+		return false, err
+	}
+	if err := json.Unmarshal([]byte(p.Value), v); err != nil {
+		return true, fmt.Errorf("invalid JSON in property %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// SetJSONProperty sets the property name to the JSON encoding of v, with type
+// JSON. The encoding must not exceed the maximum length of a property value.
+func (s *ClusterProfileStatus) SetJSONProperty(name string, v any, observedTime metav1.Time) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode property %s: %w", name, err)
+	}
+	return s.SetTypedProperty(name, PropertyValueTypeJSON, string(value), observedTime)
+}
@@ -0,0 +1,229 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+var _ = ginkgo.Describe("Well-known properties", func() {
+	var status *ClusterProfileStatus
+	observed := metav1.NewTime(time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC))
+
+	ginkgo.BeforeEach(func() {
+		status = &ClusterProfileStatus{
+			Properties: []Property{{Name: "custom", Value: "kept"}},
+		}
+	})
+
+	ginkgo.It("should set and get string properties", func() {
+		gomega.Expect(status.SetClusterID("spoke-1.fleet.example", observed)).To(gomega.Succeed())
+		gomega.Expect(status.SetClusterSet("fleet", observed)).To(gomega.Succeed())
+		gomega.Expect(status.SetRegion("us-east-1", observed)).To(gomega.Succeed())
+		gomega.Expect(status.SetCloudProvider("aws", observed)).To(gomega.Succeed())
+		gomega.Expect(status.SetDistribution("eks", observed)).To(gomega.Succeed())
+
+		gomega.Expect(status.ClusterID()).To(gomega.Equal("spoke-1.fleet.example"))
+		gomega.Expect(status.ClusterSet()).To(gomega.Equal("fleet"))
+		gomega.Expect(status.Region()).To(gomega.Equal("us-east-1"))
+		gomega.Expect(status.CloudProvider()).To(gomega.Equal("aws"))
+		gomega.Expect(status.Distribution()).To(gomega.Equal("eks"))
+		gomega.Expect(status.Properties).To(gomega.ContainElement(Property{
+			Name: PropertyClusterID, Value: "spoke-1.fleet.example", LastObservedTime: observed,
+		}))
+		gomega.Expect(status.Properties).To(gomega.ContainElement(Property{Name: "custom", Value: "kept"}))
+	})
+
+	ginkgo.It("should update an existing property in place and refresh its observation time", func() {
+		gomega.Expect(status.SetRegion("us-east-1", observed)).To(gomega.Succeed())
+		later := metav1.NewTime(observed.Add(time.Minute))
+		gomega.Expect(status.SetRegion("us-west-2", later)).To(gomega.Succeed())
+
+		gomega.Expect(status.Properties).To(gomega.HaveLen(2))
+		gomega.Expect(status.Properties[1]).To(gomega.Equal(Property{
+			Name: PropertyRegion, Value: "us-west-2", LastObservedTime: later,
+		}))
+	})
+
+	ginkgo.It("should remove a property set to an empty value", func() {
+		gomega.Expect(status.SetRegion("us-east-1", observed)).To(gomega.Succeed())
+		gomega.Expect(status.SetRegion("", observed)).To(gomega.Succeed())
+		gomega.Expect(status.Region()).To(gomega.BeEmpty())
+		gomega.Expect(status.Properties).To(gomega.HaveLen(1))
+	})
+
+	ginkgo.It("should reject invalid values", func() {
+		gomega.Expect(status.SetClusterID("Not A DNS Name", observed)).To(
+			gomega.MatchError(gomega.ContainSubstring("invalid value \"Not A DNS Name\" for property cluster.clusterset.k8s.io")))
+		gomega.Expect(status.SetRegion("us east", observed)).NotTo(gomega.Succeed())
+		gomega.Expect(status.SetQuantityProperty("custom", resource.MustParse("1"), observed)).To(gomega.Succeed())
+		gomega.Expect(status.setProperty("custom", strings.Repeat("a", 1025), observed)).To(
+			gomega.MatchError(gomega.ContainSubstring("no more than 1024 characters")))
+		gomega.Expect(status.Region()).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should store lists sorted and de-duplicated", func() {
+		gomega.Expect(status.SetZones([]string{"us-east-1b", "us-east-1a", "us-east-1b"}, observed)).To(gomega.Succeed())
+		gomega.Expect(status.SetNodeArchitectures([]string{"arm64", "amd64"}, observed)).To(gomega.Succeed())
+
+		gomega.Expect(status.Zones()).To(gomega.Equal([]string{"us-east-1a", "us-east-1b"}))
+		gomega.Expect(status.NodeArchitectures()).To(gomega.Equal([]string{"amd64", "arm64"}))
+		gomega.Expect(status.property(PropertyNodeArchitectures).Value).To(gomega.Equal("amd64,arm64"))
+		gomega.Expect(ValidateWellKnownProperty(PropertyZones, "a,,b")).NotTo(gomega.BeEmpty())
+
+		gomega.Expect(status.SetZones(nil, observed)).To(gomega.Succeed())
+		gomega.Expect(status.Zones()).To(gomega.BeNil())
+	})
+
+	ginkgo.It("should parse and format semantic versions", func() {
+		v, err := status.DistributionVersion()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(v).To(gomega.BeNil())
+
+		gomega.Expect(status.SetDistributionVersion(version.MustParseSemantic("v4.15.2"), observed)).To(gomega.Succeed())
+		gomega.Expect(status.property(PropertyDistributionVersion).Value).To(gomega.Equal("4.15.2"))
+		v, err = status.DistributionVersion()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(v.AtLeast(version.MustParseSemantic("4.14.0"))).To(gomega.BeTrue())
+
+		status.Properties = append(status.Properties, Property{Name: "custom-version", Value: "4.15"})
+		_, err = status.SemverProperty("custom-version")
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("invalid semantic version \"4.15\"")))
+		gomega.Expect(ValidateWellKnownProperty(PropertyDistributionVersion, "4.15")).NotTo(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should parse and format quantities", func() {
+		gomega.Expect(status.SetQuantityProperty("example.com/gpu-memory", resource.MustParse("80Gi"), observed)).To(gomega.Succeed())
+		q, err := status.QuantityProperty("example.com/gpu-memory")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(q.Cmp(resource.MustParse("81920Mi"))).To(gomega.Equal(0))
+
+		q, err = status.QuantityProperty("missing")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(q).To(gomega.BeNil())
+
+		_, err = status.QuantityProperty("custom")
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("invalid quantity \"kept\"")))
+	})
+})
+
+var _ = ginkgo.Describe("Typed properties", func() {
+	var status *ClusterProfileStatus
+	observed := metav1.NewTime(time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC))
+
+	ginkgo.BeforeEach(func() {
+		status = &ClusterProfileStatus{}
+	})
+
+	ginkgo.It("should set and get integers and booleans", func() {
+		gomega.Expect(status.SetIntegerProperty("example.com/gpus", 8, observed)).To(gomega.Succeed())
+		gomega.Expect(status.SetBooleanProperty("example.com/spot", true, observed)).To(gomega.Succeed())
+		gomega.Expect(status.Properties).To(gomega.ConsistOf(
+			Property{Name: "example.com/gpus", Value: "8", Type: PropertyValueTypeInteger, LastObservedTime: observed},
+			Property{Name: "example.com/spot", Value: "true", Type: PropertyValueTypeBoolean, LastObservedTime: observed},
+		))
+
+		i, err := status.IntegerProperty("example.com/gpus")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(*i).To(gomega.Equal(int64(8)))
+		b, err := status.BooleanProperty("example.com/spot")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(*b).To(gomega.BeTrue())
+
+		i, err = status.IntegerProperty("missing")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(i).To(gomega.BeNil())
+	})
+
+	ginkgo.It("should set and get JSON documents", func() {
+		type gpu struct {
+			Model string `json:"model"`
+			Count int    `json:"count"`
+		}
+		gomega.Expect(status.SetJSONProperty("example.com/gpu", gpu{Model: "h100", Count: 8}, observed)).To(gomega.Succeed())
+		gomega.Expect(status.property("example.com/gpu").Value).To(gomega.Equal(`{"model":"h100","count":8}`))
+
+		var got gpu
+		ok, err := status.JSONProperty("example.com/gpu", &got)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(ok).To(gomega.BeTrue())
+		gomega.Expect(got).To(gomega.Equal(gpu{Model: "h100", Count: 8}))
+
+		ok, err = status.JSONProperty("missing", &got)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(ok).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should refuse to read a property as another type", func() {
+		gomega.Expect(status.SetQuantityProperty("example.com/gpus", resource.MustParse("8"), observed)).To(gomega.Succeed())
+		gomega.Expect(status.property("example.com/gpus").Type).To(gomega.Equal(PropertyValueTypeQuantity))
+		_, err := status.IntegerProperty("example.com/gpus")
+		gomega.Expect(err).To(gomega.MatchError("property example.com/gpus has type Quantity, not Integer"))
+	})
+
+	ginkgo.It("should read untyped properties as any type", func() {
+		status.Properties = []Property{{Name: "example.com/gpus", Value: "8"}}
+		gomega.Expect(status.Properties[0].ValueType()).To(gomega.Equal(PropertyValueTypeString))
+		i, err := status.IntegerProperty("example.com/gpus")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(*i).To(gomega.Equal(int64(8)))
+		q, err := status.QuantityProperty("example.com/gpus")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(q.Value()).To(gomega.Equal(int64(8)))
+	})
+
+	ginkgo.It("should validate values against their type", func() {
+		valid := map[PropertyValueType]string{
+			"":                        "anything",
+			PropertyValueTypeString:   "anything",
+			PropertyValueTypeInteger:  "-42",
+			PropertyValueTypeQuantity: "1500m",
+			PropertyValueTypeSemver:   "v1.35.0",
+			PropertyValueTypeBoolean:  "false",
+			PropertyValueTypeJSON:     `["a"]`,
+		}
+		for valueType, value := range valid {
+			gomega.Expect(ValidatePropertyValue(valueType, value)).To(gomega.BeEmpty(), string(valueType))
+		}
+		for _, value := range []string{"9223372036854775807", "-9223372036854775808", "+0009223372036854775"} {
+			gomega.Expect(ValidatePropertyValue(PropertyValueTypeInteger, value)).To(gomega.BeEmpty(), value)
+		}
+		for _, value := range []string{"9223372036854775808", "-9223372036854775809", "00009223372036854775807"} {
+			gomega.Expect(ValidatePropertyValue(PropertyValueTypeInteger, value)).NotTo(gomega.BeEmpty(), value)
+		}
+		invalid := map[PropertyValueType]string{
+			PropertyValueTypeInteger:  "1.5",
+			PropertyValueTypeQuantity: "lots",
+			PropertyValueTypeSemver:   "1.35",
+			PropertyValueTypeBoolean:  "yes",
+			PropertyValueTypeJSON:     "{",
+			"Float":                   "1.5",
+		}
+		for valueType, value := range invalid {
+			gomega.Expect(ValidatePropertyValue(valueType, value)).NotTo(gomega.BeEmpty(), string(valueType))
+		}
+		gomega.Expect(status.SetJSONProperty("example.com/big", strings.Repeat("a", 1024), observed)).To(
+			gomega.MatchError(gomega.ContainSubstring("no more than 1024 characters")))
+	})
+})
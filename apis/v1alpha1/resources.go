@@ -0,0 +1,135 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// SumResourceSummaries returns the sum of summaries, for example the resources
+// of all clusters of a ClusterSet. Nil summaries are skipped.
+// NodeCount is only set if it is set in every summary, and LastObservedTime is
+// the oldest observation, so the sum never looks fresher than its parts.
+func SumResourceSummaries(summaries ...*ResourceSummary) *ResourceSummary {
+	var sum *ResourceSummary
+	nodeCountKnown := true
+	var nodeCount int32
+	for _, s := range summaries {
+		if s == nil {
+			continue
+		}
+		if sum == nil {
+			sum = &ResourceSummary{LastObservedTime: s.LastObservedTime}
+		} else if s.LastObservedTime.Before(&sum.LastObservedTime) {
+			sum.LastObservedTime = s.LastObservedTime
+		}
+		sum.Capacity = AddResourceLists(sum.Capacity, s.Capacity)
+		sum.Allocatable = AddResourceLists(sum.Allocatable, s.Allocatable)
+		if s.NodeCount == nil {
+			nodeCountKnown = false
+		} else {
+			nodeCount += *s.NodeCount
+		}
+	}
+	if sum != nil && nodeCountKnown {
+		sum.NodeCount = &nodeCount
+	}
+	return sum
+}
+
+// AddResourceLists returns a new ResourceList holding a + b.
+func AddResourceLists(a, b corev1.ResourceList) corev1.ResourceList {
+	if a == nil && b == nil {
+		return nil
+	}
+	sum := make(corev1.ResourceList, len(a))
+	for name, q := range a {
+		sum[name] = q.DeepCopy()
+	}
+	for name, q := range b {
+		total := sum[name]
+		total.Add(q)
+		sum[name] = total
+	}
+	return sum
+}
+
+// EqualResourceLists reports whether a and b hold the same amounts. Quantities
+// are compared by value, so "1" and "1000m" are equal, and a missing resource
+// equals a zero quantity.
+func EqualResourceLists(a, b corev1.ResourceList) bool {
+	for name, q := range a {
+		other := b[name]
+		if q.Cmp(other) != 0 {
+			return false
+		}
+	}
+	for name, q := range b {
+		if _, ok := a[name]; !ok && !q.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether s and other describe the same resources, ignoring
+// LastObservedTime. Cluster managers can use it to skip status updates that
+// would only refresh the observation time.
+func (s *ResourceSummary) Equal(other *ResourceSummary) bool {
+	if s == nil || other == nil {
+		return s == other
+	}
+	if (s.NodeCount == nil) != (other.NodeCount == nil) ||
+		(s.NodeCount != nil && *s.NodeCount != *other.NodeCount) {
+		return false
+	}
+	return EqualResourceLists(s.Capacity, other.Capacity) && EqualResourceLists(s.Allocatable, other.Allocatable)
+}
+
+// Fits reports whether every resource in requests is available in Allocatable
+// in at least the requested amount. A nil summary fits nothing but empty requests.
+func (s *ResourceSummary) Fits(requests corev1.ResourceList) bool {
+	for name, req := range requests {
+		if req.IsZero() {
+			continue
+		}
+		if s == nil {
+			return false
+		}
+		available, ok := s.Allocatable[name]
+		if !ok || available.Cmp(req) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// CompareAllocatable compares the allocatable amount of name in a and b. It
+// returns -1 if a has less, 0 if both have the same, and 1 if a has more. A
+// nil summary or a missing resource counts as zero.
+func CompareAllocatable(a, b *ResourceSummary, name corev1.ResourceName) int {
+	qa := allocatable(a, name)
+	return qa.Cmp(allocatable(b, name))
+}
+
+func allocatable(s *ResourceSummary, name corev1.ResourceName) resource.Quantity {
+	if s == nil {
+		return resource.Quantity{}
+	}
+	return s.Allocatable[name]
+}
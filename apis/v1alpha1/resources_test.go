@@ -0,0 +1,122 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestV1alpha1(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "v1alpha1 API Suite")
+}
+
+func resources(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+var _ = ginkgo.Describe("ResourceSummary", func() {
+	observed := metav1.NewTime(time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC))
+
+	ginkgo.It("should sum summaries and keep the oldest observation", func() {
+		sum := SumResourceSummaries(
+			&ResourceSummary{
+				Capacity:         resources("8", "32Gi"),
+				Allocatable:      resources("7500m", "30Gi"),
+				NodeCount:        ptr.To[int32](2),
+				LastObservedTime: observed,
+			},
+			nil,
+			&ResourceSummary{
+				Capacity:         resources("4", "16Gi"),
+				Allocatable:      corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3500m")},
+				NodeCount:        ptr.To[int32](1),
+				LastObservedTime: metav1.NewTime(observed.Add(-time.Minute)),
+			},
+		)
+		gomega.Expect(sum.Capacity).To(gomega.HaveLen(2))
+		gomega.Expect(EqualResourceLists(sum.Capacity, resources("12", "48Gi"))).To(gomega.BeTrue())
+		gomega.Expect(EqualResourceLists(sum.Allocatable, resources("11", "30Gi"))).To(gomega.BeTrue())
+		gomega.Expect(sum.NodeCount).To(gomega.HaveValue(gomega.Equal(int32(3))))
+		gomega.Expect(sum.LastObservedTime.Time).To(gomega.Equal(observed.Add(-time.Minute)))
+	})
+
+	ginkgo.It("should not report a node count unless every summary has one", func() {
+		sum := SumResourceSummaries(
+			&ResourceSummary{NodeCount: ptr.To[int32](2)},
+			&ResourceSummary{Capacity: resources("1", "1Gi")},
+		)
+		gomega.Expect(sum.NodeCount).To(gomega.BeNil())
+		gomega.Expect(SumResourceSummaries(nil)).To(gomega.BeNil())
+	})
+
+	ginkgo.It("should not modify the summed lists", func() {
+		a := resources("1", "1Gi")
+		AddResourceLists(a, resources("1", "1Gi"))
+		gomega.Expect(EqualResourceLists(a, resources("1", "1Gi"))).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("should compare quantities by value", func() {
+		gomega.Expect(EqualResourceLists(resources("1", "1Gi"), resources("1000m", "1024Mi"))).To(gomega.BeTrue())
+		gomega.Expect(EqualResourceLists(resources("1", "1Gi"), resources("2", "1Gi"))).To(gomega.BeFalse())
+		gomega.Expect(EqualResourceLists(
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourcePods: resource.MustParse("0")},
+		)).To(gomega.BeTrue())
+		gomega.Expect(EqualResourceLists(resources("1", "1Gi"), corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")})).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should compare summaries ignoring the observation time", func() {
+		a := &ResourceSummary{Allocatable: resources("1", "1Gi"), NodeCount: ptr.To[int32](1), LastObservedTime: observed}
+		b := &ResourceSummary{Allocatable: resources("1000m", "1Gi"), NodeCount: ptr.To[int32](1)}
+		gomega.Expect(a.Equal(b)).To(gomega.BeTrue())
+
+		b.NodeCount = ptr.To[int32](2)
+		gomega.Expect(a.Equal(b)).To(gomega.BeFalse())
+		gomega.Expect(a.Equal(nil)).To(gomega.BeFalse())
+		gomega.Expect((*ResourceSummary)(nil).Equal(nil)).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("should check whether requests fit into allocatable", func() {
+		s := &ResourceSummary{Allocatable: resources("4", "8Gi")}
+		gomega.Expect(s.Fits(resources("4", "8Gi"))).To(gomega.BeTrue())
+		gomega.Expect(s.Fits(resources("4500m", "1Gi"))).To(gomega.BeFalse())
+		gomega.Expect(s.Fits(corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")})).To(gomega.BeFalse())
+		gomega.Expect((*ResourceSummary)(nil).Fits(resources("1", "1Gi"))).To(gomega.BeFalse())
+		gomega.Expect((*ResourceSummary)(nil).Fits(nil)).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("should order summaries by allocatable amount", func() {
+		small := &ResourceSummary{Allocatable: resources("2", "8Gi")}
+		large := &ResourceSummary{Allocatable: resources("16", "8Gi")}
+		gomega.Expect(CompareAllocatable(small, large, corev1.ResourceCPU)).To(gomega.Equal(-1))
+		gomega.Expect(CompareAllocatable(large, small, corev1.ResourceCPU)).To(gomega.Equal(1))
+		gomega.Expect(CompareAllocatable(small, large, corev1.ResourceMemory)).To(gomega.Equal(0))
+		gomega.Expect(CompareAllocatable(nil, small, corev1.ResourceCPU)).To(gomega.Equal(-1))
+	})
+})
@@ -0,0 +1,228 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+)
+
+// ClusterProfileSpec defines the desired state of ClusterProfile.
+type ClusterProfileSpec struct {
+	// DisplayName defines a human-readable name of the ClusterProfile
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+
+	// ClusterManager defines which cluster manager owns this ClusterProfile resource
+	// +required
+	ClusterManager ClusterManager `json:"clusterManager"`
+}
+
+// ClusterManager defines which cluster manager owns this ClusterProfile resource.
+// The cluster manager must also set the x-k8s.io/cluster-manager label of the
+// ClusterProfile to its name.
+//
+// This field is immutable.
+//
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="ClusterManager is immutable"
+type ClusterManager struct {
+	// Name defines the name of the cluster manager
+	// +required
+	Name string `json:"name"`
+}
+
+// ClusterProfileStatus defines the observed state of ClusterProfile.
+// The condition types, condition reasons and well-known property names defined
+// in v1alpha1 apply unchanged.
+type ClusterProfileStatus struct {
+	// Conditions contains the different condition statuses for this cluster.
+	// The predefined condition types are ControlPlaneHealthy, Joined, Reachable,
+	// AccessProvidersReady and Deleting. Unknown means the cluster manager could not
+	// determine the status and must not be treated as True.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Version defines the version information of the cluster.
+	// +optional
+	Version ClusterVersion `json:"version,omitempty"`
+
+	// Properties defines cluster characteristics through a list of Property objects.
+	// Property names are either well-known names, such as the names of ClusterProperty
+	// resources defined in KEP-2149, or custom names defined by cluster managers.
+	// The list is capped at 4096 entries to bound the cost of validating typed values.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=4096
+	Properties []Property `json:"properties,omitempty"`
+
+	// AccessProviders is a list of cluster access providers that can provide access
+	// information for clusters.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=32
+	AccessProviders []AccessProvider `json:"accessProviders,omitempty"`
+
+	// Resources summarizes the compute resources of the cluster.
+	// +optional
+	Resources *ResourceSummary `json:"resources,omitempty"`
+}
+
+// AccessProvider defines how to access the cluster.
+type AccessProvider struct {
+	// Name identifies the type of access, such as "kubeconfig" or "oidc".
+	// Consumers use it to find the provider they are configured for.
+	// +kubebuilder:validation:MaxLength=253
+	// +required
+	Name string `json:"name"`
+
+	// Cluster contains the connection details of the cluster, such as the server
+	// address, the certificate authority data and extensions for exec plugins.
+	// +optional
+	Cluster clientcmdv1.Cluster `json:"cluster,omitempty"`
+
+	// Labels describe the provider, for example the network path it uses or the
+	// kind of identity it grants, so that consumers can choose between the
+	// providers of a cluster without knowing the cluster manager.
+	// +kubebuilder:validation:MaxProperties=16
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ClusterVersion represents version information about the cluster.
+type ClusterVersion struct {
+	// Kubernetes is the kubernetes version of the cluster.
+	// +optional
+	Kubernetes string `json:"kubernetes,omitempty"`
+}
+
+// ResourceSummary summarizes the compute resources of the nodes of a cluster.
+type ResourceSummary struct {
+	// Capacity is the total amount of each resource of the nodes counted in NodeCount,
+	// as reported in their status.capacity.
+	// +optional
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+
+	// Allocatable is the total amount of each resource of the nodes counted in NodeCount
+	// that is available for scheduling, as reported in their status.allocatable.
+	// +optional
+	Allocatable corev1.ResourceList `json:"allocatable,omitempty"`
+
+	// NodeCount is the number of nodes included in Capacity and Allocatable.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	NodeCount *int32 `json:"nodeCount,omitempty"`
+
+	// LastObservedTime is the last time the resources were observed on the cluster.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=date-time
+	// +optional
+	LastObservedTime metav1.Time `json:"lastObservedTime,omitempty"`
+}
+
+// Property is a name/value pair describing the cluster, and the last time it
+// was observed on the cluster.
+// +kubebuilder:validation:XValidation:rule="!has(self.type) || self.type != 'Integer' || (self.value.matches('^[+-]?[0-9]+$') && (self.value.size() - (self.value.startsWith('+') || self.value.startsWith('-') ? 1 : 0) < 19 || (self.value.size() - (self.value.startsWith('+') || self.value.startsWith('-') ? 1 : 0) == 19 && (self.value.substring(self.value.size() - 19) <= '9223372036854775807' || self.value == '-9223372036854775808'))))",message="value must be a 64-bit integer"
+// +kubebuilder:validation:XValidation:rule="!has(self.type) || self.type != 'Quantity' || isQuantity(self.value)",message="value must be a quantity"
+// +kubebuilder:validation:XValidation:rule="!has(self.type) || self.type != 'Semver' || isSemver(self.value.startsWith('v') ? self.value.substring(1) : self.value)",message="value must be a semantic version"
+// +kubebuilder:validation:XValidation:rule="!has(self.type) || self.type != 'Boolean' || self.value in ['true', 'false']",message="value must be true or false"
+type Property struct {
+	// Name is the name of a property resource on cluster. It's a well-known
+	// or customized name to identify the property.
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Name string `json:"name"`
+
+	// Value is a property-dependent string
+	// +kubebuilder:validation:MaxLength=1024
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Value string `json:"value"`
+
+	// Type is a hint about how to interpret Value. If set, Value must be valid for
+	// the type; JSON values are checked by the validating webhook, the other types
+	// by the CRD schema. Properties without a type are plain strings, as before
+	// the field was introduced.
+	// +optional
+	Type PropertyValueType `json:"type,omitempty"`
+
+	// LastObservedTime is the last time the property was observed on the corresponding cluster.
+	// The value is the timestamp when the property was observed not the time when the property
+	// was updated in the cluster-profile.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=date-time
+	// +optional
+	LastObservedTime metav1.Time `json:"lastObservedTime,omitempty"`
+}
+
+// PropertyValueType is the type of the value of a Property.
+// +kubebuilder:validation:Enum=String;Integer;Quantity;Semver;Boolean;JSON
+type PropertyValueType string
+
+const (
+	// PropertyValueTypeString is a string without further structure.
+	PropertyValueTypeString PropertyValueType = "String"
+	// PropertyValueTypeInteger is a 64-bit signed integer in decimal notation,
+	// with an optional sign and at most 19 digits including leading zeros.
+	PropertyValueTypeInteger PropertyValueType = "Integer"
+	// PropertyValueTypeQuantity is a resource.Quantity, e.g. "80Gi".
+	PropertyValueTypeQuantity PropertyValueType = "Quantity"
+	// PropertyValueTypeSemver is a semantic version, with an optional leading "v".
+	PropertyValueTypeSemver PropertyValueType = "Semver"
+	// PropertyValueTypeBoolean is "true" or "false".
+	PropertyValueTypeBoolean PropertyValueType = "Boolean"
+	// PropertyValueTypeJSON is a JSON document.
+	PropertyValueTypeJSON PropertyValueType = "JSON"
+)
+
+//+genclient
+//+kubebuilder:object:root=true
+//+kubebuilder:unservedversion
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced
+//+kubebuilder:selectablefield:JSONPath=".spec.clusterManager.name"
+//+kubebuilder:selectablefield:JSONPath=".spec.displayName"
+
+// ClusterProfile represents a single cluster in a multi-cluster deployment.
+type ClusterProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +required
+	Spec ClusterProfileSpec `json:"spec"`
+
+	// +optional
+	Status ClusterProfileStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterProfileList contains a list of ClusterProfile.
+type ClusterProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterProfile `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterProfile{}, &ClusterProfileList{})
+}
@@ -0,0 +1,207 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+)
+
+// ConversionDataAnnotation holds the fields that the version of an object
+// cannot represent, so that converting it back to the version they came from
+// restores them: the CredentialProviders of a v1alpha1 ClusterProfile read as
+// v1alpha2, and the access provider labels of a v1alpha2 ClusterProfile
+// stored as v1alpha1. Clients must not set it.
+const ConversionDataAnnotation = "multicluster.x-k8s.io/conversion-data"
+
+// conversionData is the content of ConversionDataAnnotation.
+type conversionData struct {
+	// CredentialProviders are the v1alpha1 CredentialProviders.
+	CredentialProviders []v1alpha1.AccessProvider `json:"credentialProviders,omitempty"`
+	// AccessProviderLabels are the v1alpha2 labels of the access providers, by provider name.
+	AccessProviderLabels map[string]map[string]string `json:"accessProviderLabels,omitempty"`
+}
+
+var (
+	_ conversion.Convertible = &ClusterProfile{}
+	_ conversion.Convertible = &PlacementDecision{}
+)
+
+// ConvertTo converts src to the v1alpha1 hub.
+func (src *ClusterProfile) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha1.ClusterProfile)
+	if !ok {
+		return fmt.Errorf("unsupported hub type %T", dstRaw)
+	}
+	data, err := popConversionData(&src.ObjectMeta, &dst.ObjectMeta)
+	if err != nil {
+		return err
+	}
+
+	dst.Spec = v1alpha1.ClusterProfileSpec{
+		DisplayName:    src.Spec.DisplayName,
+		ClusterManager: v1alpha1.ClusterManager{Name: src.Spec.ClusterManager.Name},
+	}
+	s := src.Status.DeepCopy()
+	dst.Status = v1alpha1.ClusterProfileStatus{
+		Conditions:          s.Conditions,
+		Version:             v1alpha1.ClusterVersion{Kubernetes: s.Version.Kubernetes},
+		CredentialProviders: data.CredentialProviders,
+	}
+	for _, p := range s.Properties {
+		dst.Status.Properties = append(dst.Status.Properties, v1alpha1.Property{
+			Name:             p.Name,
+			Value:            p.Value,
+			Type:             v1alpha1.PropertyValueType(p.Type),
+			LastObservedTime: p.LastObservedTime,
+		})
+	}
+	if s.Resources != nil {
+		r := v1alpha1.ResourceSummary(*s.Resources)
+		dst.Status.Resources = &r
+	}
+
+	labels := map[string]map[string]string{}
+	for _, p := range s.AccessProviders {
+		dst.Status.AccessProviders = append(dst.Status.AccessProviders, v1alpha1.AccessProvider{
+			Name:    p.Name,
+			Cluster: p.Cluster,
+		})
+		if p.Labels != nil {
+			labels[p.Name] = p.Labels
+		}
+	}
+	return pushConversionData(&dst.ObjectMeta, conversionData{AccessProviderLabels: labels})
+}
+
+// ConvertFrom converts the v1alpha1 hub to dst.
+func (dst *ClusterProfile) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha1.ClusterProfile)
+	if !ok {
+		return fmt.Errorf("unsupported hub type %T", srcRaw)
+	}
+	data, err := popConversionData(&src.ObjectMeta, &dst.ObjectMeta)
+	if err != nil {
+		return err
+	}
+
+	dst.Spec = ClusterProfileSpec{
+		DisplayName:    src.Spec.DisplayName,
+		ClusterManager: ClusterManager{Name: src.Spec.ClusterManager.Name},
+	}
+	s := src.Status.DeepCopy()
+	dst.Status = ClusterProfileStatus{
+		Conditions: s.Conditions,
+		Version:    ClusterVersion{Kubernetes: s.Version.Kubernetes},
+	}
+	for _, p := range s.Properties {
+		dst.Status.Properties = append(dst.Status.Properties, Property{
+			Name:             p.Name,
+			Value:            p.Value,
+			Type:             PropertyValueType(p.Type),
+			LastObservedTime: p.LastObservedTime,
+		})
+	}
+	if s.Resources != nil {
+		r := ResourceSummary(*s.Resources)
+		dst.Status.Resources = &r
+	}
+	for _, p := range s.AccessProviders {
+		dst.Status.AccessProviders = append(dst.Status.AccessProviders, AccessProvider{
+			Name:    p.Name,
+			Cluster: p.Cluster,
+			Labels:  data.AccessProviderLabels[p.Name],
+		})
+	}
+	return pushConversionData(&dst.ObjectMeta, conversionData{CredentialProviders: s.CredentialProviders})
+}
+
+// ConvertTo converts src to the v1alpha1 hub.
+func (src *PlacementDecision) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha1.PlacementDecision)
+	if !ok {
+		return fmt.Errorf("unsupported hub type %T", dstRaw)
+	}
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	dst.SchedulerName = src.Spec.SchedulerName
+	dst.Decisions = nil
+	for _, d := range src.Spec.Decisions {
+		dst.Decisions = append(dst.Decisions, v1alpha1.ClusterDecision{
+			ClusterProfileRef: v1alpha1.ClusterProfileReference(d.ClusterProfileRef),
+			Reason:            d.Reason,
+		})
+	}
+	return nil
+}
+
+// ConvertFrom converts the v1alpha1 hub to dst.
+func (dst *PlacementDecision) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha1.PlacementDecision)
+	if !ok {
+		return fmt.Errorf("unsupported hub type %T", srcRaw)
+	}
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	dst.Spec = PlacementDecisionSpec{SchedulerName: src.SchedulerName}
+	for _, d := range src.Decisions {
+		dst.Spec.Decisions = append(dst.Spec.Decisions, ClusterDecision{
+			ClusterProfileRef: ClusterProfileReference(d.ClusterProfileRef),
+			Reason:            d.Reason,
+		})
+	}
+	return nil
+}
+
+// popConversionData copies src to dst without ConversionDataAnnotation, and
+// returns the content of the annotation.
+func popConversionData(src, dst *metav1.ObjectMeta) (conversionData, error) {
+	src.DeepCopyInto(dst)
+	var data conversionData
+	raw, ok := dst.Annotations[ConversionDataAnnotation]
+	if !ok {
+		return data, nil
+	}
+	delete(dst.Annotations, ConversionDataAnnotation)
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		return data, fmt.Errorf("invalid %s annotation: %w", ConversionDataAnnotation, err)
+	}
+	return data, nil
+}
+
+// pushConversionData stores data in ConversionDataAnnotation of meta, unless
+// there is nothing to store.
+func pushConversionData(meta *metav1.ObjectMeta, data conversionData) error {
+	if len(data.CredentialProviders) == 0 && len(data.AccessProviderLabels) == 0 {
+		return nil
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s annotation: %w", ConversionDataAnnotation, err)
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[ConversionDataAnnotation] = string(raw)
+	return nil
+}
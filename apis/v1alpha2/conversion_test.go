@@ -0,0 +1,174 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/randfill"
+
+	"sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+)
+
+func TestV1alpha2(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "v1alpha2 API Suite")
+}
+
+const fuzzIterations = 500
+
+// newFiller returns a filler that produces objects the API server could store:
+// JSON extension payloads, canonical quantities, second-precision times, and
+// access providers with unique names.
+func newFiller(seed int64) *randfill.Filler {
+	return randfill.NewWithSeed(seed).NilChance(0.2).NumElements(0, 3).Funcs(
+		func(t *metav1.TypeMeta, _ randfill.Continue) {},
+		func(t *metav1.Time, c randfill.Continue) {
+			*t = metav1.Unix(c.Int63n(1<<32), 0)
+		},
+		func(m *metav1.ObjectMeta, c randfill.Continue) {
+			m.Name = c.String(0)
+			m.Namespace = c.String(0)
+			m.Generation = c.Int63()
+			c.Fill(&m.Labels)
+			c.Fill(&m.Annotations)
+		},
+		func(q *resource.Quantity, c randfill.Continue) {
+			*q = *resource.NewQuantity(c.Int63n(1000), resource.DecimalSI)
+		},
+		func(e *runtime.RawExtension, c randfill.Continue) {
+			e.Raw = []byte(fmt.Sprintf(`{"value":%d}`, c.Int63()))
+		},
+		func(providers *[]v1alpha1.AccessProvider, c randfill.Continue) {
+			c.FillNoCustom(providers)
+			for i := range *providers {
+				(*providers)[i].Name = fmt.Sprintf("provider-%d", i)
+			}
+		},
+		func(providers *[]AccessProvider, c randfill.Continue) {
+			c.FillNoCustom(providers)
+			for i := range *providers {
+				(*providers)[i].Name = fmt.Sprintf("provider-%d", i)
+			}
+		},
+	)
+}
+
+func expectSemanticEqual(got, want any) {
+	gomega.ExpectWithOffset(1, equality.Semantic.DeepEqual(got, want)).To(gomega.BeTrue(),
+		"round trip changed the object:\n%s", cmp.Diff(want, got))
+}
+
+var _ = ginkgo.Describe("ClusterProfile conversion", func() {
+	ginkgo.It("should round-trip v1alpha1 through v1alpha2", func() {
+		seed := ginkgo.GinkgoRandomSeed()
+		for i := range fuzzIterations {
+			filler := newFiller(seed + int64(i))
+			hub := &v1alpha1.ClusterProfile{}
+			filler.Fill(hub)
+
+			spoke := &ClusterProfile{}
+			gomega.Expect(spoke.ConvertFrom(hub.DeepCopy())).To(gomega.Succeed())
+			got := &v1alpha1.ClusterProfile{}
+			gomega.Expect(spoke.ConvertTo(got)).To(gomega.Succeed())
+			expectSemanticEqual(got, hub)
+		}
+	})
+
+	ginkgo.It("should round-trip v1alpha2 through v1alpha1", func() {
+		seed := ginkgo.GinkgoRandomSeed()
+		for i := range fuzzIterations {
+			filler := newFiller(seed + int64(i))
+			spoke := &ClusterProfile{}
+			filler.Fill(spoke)
+
+			hub := &v1alpha1.ClusterProfile{}
+			gomega.Expect(spoke.DeepCopy().ConvertTo(hub)).To(gomega.Succeed())
+			got := &ClusterProfile{}
+			gomega.Expect(got.ConvertFrom(hub)).To(gomega.Succeed())
+			expectSemanticEqual(got, spoke)
+		}
+	})
+
+	ginkgo.It("should keep access provider labels out of v1alpha1 fields", func() {
+		spoke := &ClusterProfile{
+			Status: ClusterProfileStatus{AccessProviders: []AccessProvider{{
+				Name:   "secretreader",
+				Labels: map[string]string{"network": "private"},
+			}}},
+		}
+		hub := &v1alpha1.ClusterProfile{}
+		gomega.Expect(spoke.ConvertTo(hub)).To(gomega.Succeed())
+		gomega.Expect(hub.Status.AccessProviders).To(gomega.Equal([]v1alpha1.AccessProvider{{Name: "secretreader"}}))
+		gomega.Expect(hub.Annotations).To(gomega.HaveKeyWithValue(ConversionDataAnnotation,
+			`{"accessProviderLabels":{"secretreader":{"network":"private"}}}`))
+	})
+
+	ginkgo.It("should not modify the converted object", func() {
+		hub := &v1alpha1.ClusterProfile{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+				ConversionDataAnnotation: `{"accessProviderLabels":{"a":{"k":"v"}}}`,
+			}},
+			Status: v1alpha1.ClusterProfileStatus{
+				AccessProviders:     []v1alpha1.AccessProvider{{Name: "a"}},
+				CredentialProviders: []v1alpha1.AccessProvider{{Name: "b"}},
+			},
+		}
+		original := hub.DeepCopy()
+		gomega.Expect((&ClusterProfile{}).ConvertFrom(hub)).To(gomega.Succeed())
+		gomega.Expect(hub).To(gomega.Equal(original))
+	})
+
+	ginkgo.It("should reject a malformed conversion annotation", func() {
+		hub := &v1alpha1.ClusterProfile{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			ConversionDataAnnotation: "{",
+		}}}
+		gomega.Expect((&ClusterProfile{}).ConvertFrom(hub)).To(gomega.MatchError(
+			gomega.ContainSubstring(ConversionDataAnnotation)))
+	})
+})
+
+var _ = ginkgo.Describe("PlacementDecision conversion", func() {
+	ginkgo.It("should round-trip in both directions", func() {
+		r := rand.New(rand.NewSource(ginkgo.GinkgoRandomSeed()))
+		for range fuzzIterations {
+			filler := newFiller(r.Int63())
+			hub := &v1alpha1.PlacementDecision{}
+			filler.Fill(hub)
+			spoke := &PlacementDecision{}
+			gomega.Expect(spoke.ConvertFrom(hub)).To(gomega.Succeed())
+			gotHub := &v1alpha1.PlacementDecision{}
+			gomega.Expect(spoke.ConvertTo(gotHub)).To(gomega.Succeed())
+			expectSemanticEqual(gotHub, hub)
+
+			filler.Fill(spoke)
+			gomega.Expect(spoke.ConvertTo(hub)).To(gomega.Succeed())
+			gotSpoke := &PlacementDecision{}
+			gomega.Expect(gotSpoke.ConvertFrom(hub)).To(gomega.Succeed())
+			expectSemanticEqual(gotSpoke, spoke)
+		}
+	})
+})
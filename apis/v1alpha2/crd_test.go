@@ -0,0 +1,107 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	apiextensionsinternal "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+)
+
+var _ = ginkgo.Describe("CRD manifests", func() {
+	// Serving a version other than the storage version without a conversion
+	// webhook makes the API server return objects under the wrong schema.
+	ginkgo.It("should only serve several versions with webhook conversion", func() {
+		files, err := filepath.Glob(filepath.Join("..", "..", "config", "crd", "bases", "*.yaml"))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(files).NotTo(gomega.BeEmpty())
+
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			gomega.Expect(yaml.Unmarshal(data, crd)).To(gomega.Succeed())
+
+			served := 0
+			for _, v := range crd.Spec.Versions {
+				if v.Served {
+					served++
+				}
+			}
+			if served < 2 {
+				continue
+			}
+			conversion := crd.Spec.Conversion
+			gomega.Expect(conversion).NotTo(gomega.BeNil(), file)
+			gomega.Expect(conversion.Strategy).To(gomega.Equal(apiextensionsv1.WebhookConverter), file)
+			gomega.Expect(conversion.Webhook).NotTo(gomega.BeNil(), file)
+			gomega.Expect(conversion.Webhook.ClientConfig).NotTo(gomega.BeNil(), file)
+			gomega.Expect(conversion.Webhook.ClientConfig.Service).NotTo(gomega.BeNil(), file)
+			gomega.Expect(conversion.Webhook.ClientConfig.Service.Path).To(gomega.HaveValue(gomega.Equal("/convert")), file)
+		}
+	})
+	// The webhook checks typed values with v1alpha1.ValidatePropertyValue, so
+	// the CRD rules must accept exactly the same values.
+	ginkgo.It("should check Integer properties like ValidatePropertyValue", func() {
+		data, err := os.ReadFile(filepath.Join("..", "..", "config", "crd", "bases", "multicluster.x-k8s.io_clusterprofiles.yaml"))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		gomega.Expect(yaml.Unmarshal(data, crd)).To(gomega.Succeed())
+
+		for _, v := range crd.Spec.Versions {
+			props := &apiextensionsinternal.JSONSchemaProps{}
+			gomega.Expect(apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(
+				v.Schema.OpenAPIV3Schema, props, nil)).To(gomega.Succeed())
+			s, err := schema.NewStructural(props)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			validator := cel.NewValidator(s, true, celconfig.PerCallLimit)
+
+			for _, value := range []string{
+				"0", "-0", "+42", "007", "9223372036854775807", "-9223372036854775808", "+0009223372036854775",
+				"9223372036854775808", "-9223372036854775809", "19223372036854775807", "00009223372036854775807",
+				"-100000000000000000", "+123456789012345678", "-123456789012345678", "+1234567890123456789",
+				"1.5", "", "-", "+-1",
+			} {
+				obj := map[string]any{
+					"apiVersion": GroupVersion.Group + "/" + v.Name,
+					"kind":       "ClusterProfile",
+					"metadata":   map[string]any{"name": "spoke-1"},
+					"spec":       map[string]any{"clusterManager": map[string]any{"name": "fleet"}},
+					"status": map[string]any{"properties": []any{
+						map[string]any{"name": "example.com/gpus", "value": value, "type": "Integer"},
+					}},
+				}
+				errs, _ := validator.Validate(context.Background(), field.NewPath("root"), s, obj, nil,
+					celconfig.RuntimeCELCostBudget)
+				goErrs := v1alpha1.ValidatePropertyValue(v1alpha1.PropertyValueTypeInteger, value)
+				gomega.Expect(errs).To(gomega.HaveLen(len(goErrs)), "%s %q", v.Name, value)
+			}
+		}
+	})
+})
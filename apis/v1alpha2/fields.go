@@ -0,0 +1,54 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// Field selectors supported by the API server, in addition to metadata.name and
+// metadata.namespace. They are declared as selectableFields in the CRDs.
+const (
+	// ClusterProfileClusterManagerNameField selects ClusterProfiles by spec.clusterManager.name.
+	ClusterProfileClusterManagerNameField = "spec.clusterManager.name"
+	// ClusterProfileDisplayNameField selects ClusterProfiles by spec.displayName.
+	ClusterProfileDisplayNameField = "spec.displayName"
+
+	// PlacementDecisionSchedulerNameField selects PlacementDecisions by spec.schedulerName.
+	PlacementDecisionSchedulerNameField = "spec.schedulerName"
+)
+
+// ClusterProfileFields returns the selectable fields of profile, for matching
+// field selectors on the client side.
+func ClusterProfileFields(profile *ClusterProfile) fields.Set {
+	return fields.Set{
+		"metadata.name":                       profile.Name,
+		"metadata.namespace":                  profile.Namespace,
+		ClusterProfileClusterManagerNameField: profile.Spec.ClusterManager.Name,
+		ClusterProfileDisplayNameField:        profile.Spec.DisplayName,
+	}
+}
+
+// PlacementDecisionFields returns the selectable fields of decision, for
+// matching field selectors on the client side.
+func PlacementDecisionFields(decision *PlacementDecision) fields.Set {
+	return fields.Set{
+		"metadata.name":                     decision.Name,
+		"metadata.namespace":                decision.Namespace,
+		PlacementDecisionSchedulerNameField: decision.Spec.SchedulerName,
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha2 contains API Schema definitions for the multicluster.x-k8s.io v1alpha2 API group.
+//
+// Compared to v1alpha1, it drops the deprecated CredentialProviders field and
+// aliases, adds labels to access providers, and moves the content of a
+// PlacementDecision into its spec. v1alpha1 remains the storage version; objects
+// are converted through the conversion webhook in pkg/webhook.
+//
+// The generated CRDs do not serve v1alpha2 yet: without the Webhook conversion
+// strategy, the API server would return v1alpha1 data under the v1alpha2 schema
+// and prune the fields that moved. Serve it only on clusters that run the
+// conversion webhook and set spec.conversion in the CRDs accordingly.
+// +kubebuilder:object:generate=true
+// +groupName=multicluster.x-k8s.io
+package v1alpha2
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+const (
+	// Group is the API group.
+	Group = "multicluster.x-k8s.io"
+	// Version is the API version.
+	Version = "v1alpha2"
+
+	// ClusterProfileKind is the resource kind for ClusterProfile.
+	ClusterProfileKind     = "ClusterProfile"
+	clusterProfileResource = "clusterprofiles"
+
+	// PlacementDecisionKind is the resource kind for PlacementDecision.
+	PlacementDecisionKind     = "PlacementDecision"
+	placementDecisionResource = "placementdecisions"
+)
+
+var (
+	// GroupVersion is group version used to register these objects
+	GroupVersion = schema.GroupVersion{Group: Group, Version: Version}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
+
+	// SchemeGroupVersion generated code relies on this name
+	SchemeGroupVersion = GroupVersion
+
+	// ClusterProfileSchemeGroupVersionKind is the group, version and kind for the ClusterProfile CR.
+	ClusterProfileSchemeGroupVersionKind = GroupVersion.WithKind(ClusterProfileKind)
+
+	// ClusterProfileSchemeGroupVersionResource is the group, version and resource for the ClusterProfile CR.
+	ClusterProfileSchemeGroupVersionResource = GroupVersion.WithResource(clusterProfileResource)
+
+	// PlacementDecisionSchemeGroupVersionKind is the group, version and kind for the PlacementDecision CR.
+	PlacementDecisionSchemeGroupVersionKind = GroupVersion.WithKind(PlacementDecisionKind)
+
+	// PlacementDecisionSchemeGroupVersionResource is the group, version and resource for the PlacementDecision CR.
+	PlacementDecisionSchemeGroupVersionResource = GroupVersion.WithResource(placementDecisionResource)
+
+	// AddToScheme adds the types in this group-version to the given scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+
+// Resource generated code relies on this being here, but it logically belongs to the group
+func Resource(resource string) schema.GroupResource {
+	return schema.GroupResource{Group: GroupVersion.Group, Resource: resource}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterProfileReference contains the identifying information of a ClusterProfile.
+type ClusterProfileReference struct {
+	// Name is the name of the ClusterProfile.
+	// +required
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the ClusterProfile.
+	// If empty, the PlacementDecision's namespace is used.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ClusterDecision references a target ClusterProfile to apply workloads to.
+type ClusterDecision struct {
+	// ClusterProfileRef is a reference to the target ClusterProfile.
+	// +required
+	ClusterProfileRef ClusterProfileReference `json:"clusterProfileRef"`
+
+	// Reason is an optional explanation of why this cluster was chosen.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// PlacementDecisionSpec is the set of clusters chosen by a scheduler.
+type PlacementDecisionSpec struct {
+	// Decisions is the list of clusters chosen for this placement decision.
+	// Up to 100 ClusterDecisions per object (slice) to stay well below the etcd limit.
+	// +kubebuilder:validation:MinItems=0
+	// +kubebuilder:validation:MaxItems=100
+	// +required
+	Decisions []ClusterDecision `json:"decisions"`
+
+	// SchedulerName is the name of the scheduler that created this decision.
+	// +optional
+	SchedulerName string `json:"schedulerName,omitempty"`
+}
+
+//+genclient
+//+kubebuilder:object:root=true
+//+kubebuilder:unservedversion
+//+kubebuilder:resource:scope=Namespaced
+//+kubebuilder:selectablefield:JSONPath=".spec.schedulerName"
+
+// PlacementDecision publishes the set of clusters chosen by a scheduler at a point in time.
+// Unlike v1alpha1, the decision is held in Spec, which leaves room for a status
+// reported by consumers. The slicing and labelling rules of v1alpha1 apply unchanged.
+type PlacementDecision struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +required
+	Spec PlacementDecisionSpec `json:"spec"`
+}
+
+//+kubebuilder:object:root=true
+
+// PlacementDecisionList contains a list of PlacementDecision.
+type PlacementDecisionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PlacementDecision `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PlacementDecision{}, &PlacementDecisionList{})
+}
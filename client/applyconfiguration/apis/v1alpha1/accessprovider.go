@@ -0,0 +1,57 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/client-go/tools/clientcmd/api/v1"
+)
+
+// AccessProviderApplyConfiguration represents a declarative configuration of the AccessProvider type for use
+// with apply.
+//
+// AccessProvider defines how to access the cluster.
+// It contains the name of the provider name and the cluster connection details.
+// The name is used to identify different access info types, such as "kubeconfig" or "oidc".
+// The Cluster field contains the actual cluster connection details, such as server address,
+// certificate authority data, and authentication information.
+type AccessProviderApplyConfiguration struct {
+	Name    *string     `json:"name,omitempty"`
+	Cluster *v1.Cluster `json:"cluster,omitempty"`
+}
+
+// AccessProviderApplyConfiguration constructs a declarative configuration of the AccessProvider type for use with
+// apply.
+func AccessProvider() *AccessProviderApplyConfiguration {
+	return &AccessProviderApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *AccessProviderApplyConfiguration) WithName(value string) *AccessProviderApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithCluster sets the Cluster field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cluster field is set to the value of the last call.
+func (b *AccessProviderApplyConfiguration) WithCluster(value v1.Cluster) *AccessProviderApplyConfiguration {
+	b.Cluster = &value
+	return b
+}
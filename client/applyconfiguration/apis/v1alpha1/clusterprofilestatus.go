@@ -0,0 +1,130 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// ClusterProfileStatusApplyConfiguration represents a declarative configuration of the ClusterProfileStatus type for use
+// with apply.
+//
+// ClusterProfileStatus defines the observed state of ClusterProfile.
+type ClusterProfileStatusApplyConfiguration struct {
+	// Conditions contains the different condition statuses for this cluster.
+	// The predefined condition types are ControlPlaneHealthy, Joined, Reachable,
+	// AccessProvidersReady and Deleting. Unknown means the cluster manager could not
+	// determine the status and must not be treated as True.
+	Conditions []v1.ConditionApplyConfiguration `json:"conditions,omitempty"`
+	// Version defines the version information of the cluster.
+	Version *ClusterVersionApplyConfiguration `json:"version,omitempty"`
+	// Properties defines cluster characteristics through a list of Property objects.
+	// Each Property can be one of:
+	// 1. A ClusterProperty resource (as defined in KEP-2149)
+	// 2. Custom information from cluster manager implementations
+	// Property names support both:
+	// - Standard names from ClusterProperty resources
+	// - Custom names defined by cluster managers
+	// The list is capped at 4096 entries to bound the cost of validating typed values.
+	Properties []PropertyApplyConfiguration `json:"properties,omitempty"`
+	// CredentialProviders is a list of cluster access providers that can provide access
+	// information for clusters.
+	// Deprecated: Use AccessProviders instead. If both AccessProviders and CredentialProviders are provided,
+	// both are used. In case they specify a provider with the same name, the one in AccessProviders is preferred.
+	CredentialProviders []AccessProviderApplyConfiguration `json:"credentialProviders,omitempty"`
+	// AccessProviders is a list of cluster access providers that can provide access
+	// information for clusters.
+	AccessProviders []AccessProviderApplyConfiguration `json:"accessProviders,omitempty"`
+	// Resources summarizes the compute resources of the cluster.
+	// Cluster managers should report it instead of encoding capacity in Properties.
+	Resources *ResourceSummaryApplyConfiguration `json:"resources,omitempty"`
+}
+
+// ClusterProfileStatusApplyConfiguration constructs a declarative configuration of the ClusterProfileStatus type for use with
+// apply.
+func ClusterProfileStatus() *ClusterProfileStatusApplyConfiguration {
+	return &ClusterProfileStatusApplyConfiguration{}
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *ClusterProfileStatusApplyConfiguration) WithConditions(values ...*v1.ConditionApplyConfiguration) *ClusterProfileStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
+
+// WithVersion sets the Version field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Version field is set to the value of the last call.
+func (b *ClusterProfileStatusApplyConfiguration) WithVersion(value *ClusterVersionApplyConfiguration) *ClusterProfileStatusApplyConfiguration {
+	b.Version = value
+	return b
+}
+
+// WithProperties adds the given value to the Properties field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Properties field.
+func (b *ClusterProfileStatusApplyConfiguration) WithProperties(values ...*PropertyApplyConfiguration) *ClusterProfileStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithProperties")
+		}
+		b.Properties = append(b.Properties, *values[i])
+	}
+	return b
+}
+
+// WithCredentialProviders adds the given value to the CredentialProviders field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the CredentialProviders field.
+func (b *ClusterProfileStatusApplyConfiguration) WithCredentialProviders(values ...*AccessProviderApplyConfiguration) *ClusterProfileStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithCredentialProviders")
+		}
+		b.CredentialProviders = append(b.CredentialProviders, *values[i])
+	}
+	return b
+}
+
+// WithAccessProviders adds the given value to the AccessProviders field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AccessProviders field.
+func (b *ClusterProfileStatusApplyConfiguration) WithAccessProviders(values ...*AccessProviderApplyConfiguration) *ClusterProfileStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithAccessProviders")
+		}
+		b.AccessProviders = append(b.AccessProviders, *values[i])
+	}
+	return b
+}
+
+// WithResources sets the Resources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Resources field is set to the value of the last call.
+func (b *ClusterProfileStatusApplyConfiguration) WithResources(value *ResourceSummaryApplyConfiguration) *ClusterProfileStatusApplyConfiguration {
+	b.Resources = value
+	return b
+}
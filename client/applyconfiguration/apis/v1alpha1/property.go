@@ -0,0 +1,86 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apisv1alpha1 "sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+)
+
+// PropertyApplyConfiguration represents a declarative configuration of the Property type for use
+// with apply.
+//
+// Property defines the data structure to represent a property of a cluster.
+// It contains a name/value pair and the last observed time of the property on the cluster.
+// This property can store various configurable details and metrics of a cluster,
+// which may include information such as the entry point of the cluster, types of nodes, location,
+// etc. according to KEP 4322.
+type PropertyApplyConfiguration struct {
+	// Name is the name of a property resource on cluster. It's a well-known
+	// or customized name to identify the property.
+	Name *string `json:"name,omitempty"`
+	// Value is a property-dependent string
+	Value *string `json:"value,omitempty"`
+	// Type is a hint about how to interpret Value. If set, Value must be valid for
+	// the type; JSON values are checked by the validating webhook, the other types
+	// by the CRD schema. Properties without a type are plain strings, as before
+	// the field was introduced.
+	Type *apisv1alpha1.PropertyValueType `json:"type,omitempty"`
+	// LastObservedTime is the last time the property was observed on the corresponding cluster.
+	// The value is the timestamp when the property was observed not the time when the property
+	// was updated in the cluster-profile.
+	LastObservedTime *v1.Time `json:"lastObservedTime,omitempty"`
+}
+
+// PropertyApplyConfiguration constructs a declarative configuration of the Property type for use with
+// apply.
+func Property() *PropertyApplyConfiguration {
+	return &PropertyApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *PropertyApplyConfiguration) WithName(value string) *PropertyApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithValue sets the Value field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Value field is set to the value of the last call.
+func (b *PropertyApplyConfiguration) WithValue(value string) *PropertyApplyConfiguration {
+	b.Value = &value
+	return b
+}
+
+// WithType sets the Type field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Type field is set to the value of the last call.
+func (b *PropertyApplyConfiguration) WithType(value apisv1alpha1.PropertyValueType) *PropertyApplyConfiguration {
+	b.Type = &value
+	return b
+}
+
+// WithLastObservedTime sets the LastObservedTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastObservedTime field is set to the value of the last call.
+func (b *PropertyApplyConfiguration) WithLastObservedTime(value v1.Time) *PropertyApplyConfiguration {
+	b.LastObservedTime = &value
+	return b
+}
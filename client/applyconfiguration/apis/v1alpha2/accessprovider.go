@@ -0,0 +1,75 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	v1 "k8s.io/client-go/tools/clientcmd/api/v1"
+)
+
+// AccessProviderApplyConfiguration represents a declarative configuration of the AccessProvider type for use
+// with apply.
+//
+// AccessProvider defines how to access the cluster.
+type AccessProviderApplyConfiguration struct {
+	// Name identifies the type of access, such as "kubeconfig" or "oidc".
+	// Consumers use it to find the provider they are configured for.
+	Name *string `json:"name,omitempty"`
+	// Cluster contains the connection details of the cluster, such as the server
+	// address, the certificate authority data and extensions for exec plugins.
+	Cluster *v1.Cluster `json:"cluster,omitempty"`
+	// Labels describe the provider, for example the network path it uses or the
+	// kind of identity it grants, so that consumers can choose between the
+	// providers of a cluster without knowing the cluster manager.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// AccessProviderApplyConfiguration constructs a declarative configuration of the AccessProvider type for use with
+// apply.
+func AccessProvider() *AccessProviderApplyConfiguration {
+	return &AccessProviderApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *AccessProviderApplyConfiguration) WithName(value string) *AccessProviderApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithCluster sets the Cluster field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cluster field is set to the value of the last call.
+func (b *AccessProviderApplyConfiguration) WithCluster(value v1.Cluster) *AccessProviderApplyConfiguration {
+	b.Cluster = &value
+	return b
+}
+
+// WithLabels puts the entries into the Labels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Labels field,
+// overwriting an existing map entries in Labels field with the same key.
+func (b *AccessProviderApplyConfiguration) WithLabels(entries map[string]string) *AccessProviderApplyConfiguration {
+	if b.Labels == nil && len(entries) > 0 {
+		b.Labels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Labels[k] = v
+	}
+	return b
+}
@@ -0,0 +1,51 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha2
+
+// ClusterDecisionApplyConfiguration represents a declarative configuration of the ClusterDecision type for use
+// with apply.
+//
+// ClusterDecision references a target ClusterProfile to apply workloads to.
+type ClusterDecisionApplyConfiguration struct {
+	// ClusterProfileRef is a reference to the target ClusterProfile.
+	ClusterProfileRef *ClusterProfileReferenceApplyConfiguration `json:"clusterProfileRef,omitempty"`
+	// Reason is an optional explanation of why this cluster was chosen.
+	Reason *string `json:"reason,omitempty"`
+}
+
+// ClusterDecisionApplyConfiguration constructs a declarative configuration of the ClusterDecision type for use with
+// apply.
+func ClusterDecision() *ClusterDecisionApplyConfiguration {
+	return &ClusterDecisionApplyConfiguration{}
+}
+
+// WithClusterProfileRef sets the ClusterProfileRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClusterProfileRef field is set to the value of the last call.
+func (b *ClusterDecisionApplyConfiguration) WithClusterProfileRef(value *ClusterProfileReferenceApplyConfiguration) *ClusterDecisionApplyConfiguration {
+	b.ClusterProfileRef = value
+	return b
+}
+
+// WithReason sets the Reason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reason field is set to the value of the last call.
+func (b *ClusterDecisionApplyConfiguration) WithReason(value string) *ClusterDecisionApplyConfiguration {
+	b.Reason = &value
+	return b
+}
@@ -0,0 +1,45 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha2
+
+// ClusterManagerApplyConfiguration represents a declarative configuration of the ClusterManager type for use
+// with apply.
+//
+// ClusterManager defines which cluster manager owns this ClusterProfile resource.
+// The cluster manager must also set the x-k8s.io/cluster-manager label of the
+// ClusterProfile to its name.
+//
+// This field is immutable.
+type ClusterManagerApplyConfiguration struct {
+	// Name defines the name of the cluster manager
+	Name *string `json:"name,omitempty"`
+}
+
+// ClusterManagerApplyConfiguration constructs a declarative configuration of the ClusterManager type for use with
+// apply.
+func ClusterManager() *ClusterManagerApplyConfiguration {
+	return &ClusterManagerApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ClusterManagerApplyConfiguration) WithName(value string) *ClusterManagerApplyConfiguration {
+	b.Name = &value
+	return b
+}
@@ -0,0 +1,41 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha2
+
+// ClusterVersionApplyConfiguration represents a declarative configuration of the ClusterVersion type for use
+// with apply.
+//
+// ClusterVersion represents version information about the cluster.
+type ClusterVersionApplyConfiguration struct {
+	// Kubernetes is the kubernetes version of the cluster.
+	Kubernetes *string `json:"kubernetes,omitempty"`
+}
+
+// ClusterVersionApplyConfiguration constructs a declarative configuration of the ClusterVersion type for use with
+// apply.
+func ClusterVersion() *ClusterVersionApplyConfiguration {
+	return &ClusterVersionApplyConfiguration{}
+}
+
+// WithKubernetes sets the Kubernetes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Kubernetes field is set to the value of the last call.
+func (b *ClusterVersionApplyConfiguration) WithKubernetes(value string) *ClusterVersionApplyConfiguration {
+	b.Kubernetes = &value
+	return b
+}
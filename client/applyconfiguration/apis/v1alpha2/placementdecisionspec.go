@@ -0,0 +1,57 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha2
+
+// PlacementDecisionSpecApplyConfiguration represents a declarative configuration of the PlacementDecisionSpec type for use
+// with apply.
+//
+// PlacementDecisionSpec is the set of clusters chosen by a scheduler.
+type PlacementDecisionSpecApplyConfiguration struct {
+	// Decisions is the list of clusters chosen for this placement decision.
+	// Up to 100 ClusterDecisions per object (slice) to stay well below the etcd limit.
+	Decisions []ClusterDecisionApplyConfiguration `json:"decisions,omitempty"`
+	// SchedulerName is the name of the scheduler that created this decision.
+	SchedulerName *string `json:"schedulerName,omitempty"`
+}
+
+// PlacementDecisionSpecApplyConfiguration constructs a declarative configuration of the PlacementDecisionSpec type for use with
+// apply.
+func PlacementDecisionSpec() *PlacementDecisionSpecApplyConfiguration {
+	return &PlacementDecisionSpecApplyConfiguration{}
+}
+
+// WithDecisions adds the given value to the Decisions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Decisions field.
+func (b *PlacementDecisionSpecApplyConfiguration) WithDecisions(values ...*ClusterDecisionApplyConfiguration) *PlacementDecisionSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDecisions")
+		}
+		b.Decisions = append(b.Decisions, *values[i])
+	}
+	return b
+}
+
+// WithSchedulerName sets the SchedulerName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SchedulerName field is set to the value of the last call.
+func (b *PlacementDecisionSpecApplyConfiguration) WithSchedulerName(value string) *PlacementDecisionSpecApplyConfiguration {
+	b.SchedulerName = &value
+	return b
+}
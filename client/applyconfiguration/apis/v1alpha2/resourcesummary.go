@@ -0,0 +1,78 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResourceSummaryApplyConfiguration represents a declarative configuration of the ResourceSummary type for use
+// with apply.
+//
+// ResourceSummary summarizes the compute resources of the nodes of a cluster.
+type ResourceSummaryApplyConfiguration struct {
+	// Capacity is the total amount of each resource of the nodes counted in NodeCount,
+	// as reported in their status.capacity.
+	Capacity *v1.ResourceList `json:"capacity,omitempty"`
+	// Allocatable is the total amount of each resource of the nodes counted in NodeCount
+	// that is available for scheduling, as reported in their status.allocatable.
+	Allocatable *v1.ResourceList `json:"allocatable,omitempty"`
+	// NodeCount is the number of nodes included in Capacity and Allocatable.
+	NodeCount *int32 `json:"nodeCount,omitempty"`
+	// LastObservedTime is the last time the resources were observed on the cluster.
+	LastObservedTime *metav1.Time `json:"lastObservedTime,omitempty"`
+}
+
+// ResourceSummaryApplyConfiguration constructs a declarative configuration of the ResourceSummary type for use with
+// apply.
+func ResourceSummary() *ResourceSummaryApplyConfiguration {
+	return &ResourceSummaryApplyConfiguration{}
+}
+
+// WithCapacity sets the Capacity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Capacity field is set to the value of the last call.
+func (b *ResourceSummaryApplyConfiguration) WithCapacity(value v1.ResourceList) *ResourceSummaryApplyConfiguration {
+	b.Capacity = &value
+	return b
+}
+
+// WithAllocatable sets the Allocatable field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Allocatable field is set to the value of the last call.
+func (b *ResourceSummaryApplyConfiguration) WithAllocatable(value v1.ResourceList) *ResourceSummaryApplyConfiguration {
+	b.Allocatable = &value
+	return b
+}
+
+// WithNodeCount sets the NodeCount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeCount field is set to the value of the last call.
+func (b *ResourceSummaryApplyConfiguration) WithNodeCount(value int32) *ResourceSummaryApplyConfiguration {
+	b.NodeCount = &value
+	return b
+}
+
+// WithLastObservedTime sets the LastObservedTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastObservedTime field is set to the value of the last call.
+func (b *ResourceSummaryApplyConfiguration) WithLastObservedTime(value metav1.Time) *ResourceSummaryApplyConfiguration {
+	b.LastObservedTime = &value
+	return b
+}
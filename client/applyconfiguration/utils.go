@@ -0,0 +1,91 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package applyconfiguration
+
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	managedfields "k8s.io/apimachinery/pkg/util/managedfields"
+	v1alpha1 "sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+	v1alpha2 "sigs.k8s.io/cluster-inventory-api/apis/v1alpha2"
+	apisv1alpha1 "sigs.k8s.io/cluster-inventory-api/client/applyconfiguration/apis/v1alpha1"
+	apisv1alpha2 "sigs.k8s.io/cluster-inventory-api/client/applyconfiguration/apis/v1alpha2"
+	internal "sigs.k8s.io/cluster-inventory-api/client/applyconfiguration/internal"
+)
+
+// ForKind returns an apply configuration type for the given GroupVersionKind, or nil if no
+// apply configuration type exists for the given GroupVersionKind.
+func ForKind(kind schema.GroupVersionKind) interface{} {
+	switch kind {
+	// Group=apis, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithKind("AccessProvider"):
+		return &apisv1alpha1.AccessProviderApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterDecision"):
+		return &apisv1alpha1.ClusterDecisionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterManager"):
+		return &apisv1alpha1.ClusterManagerApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterProfile"):
+		return &apisv1alpha1.ClusterProfileApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterProfileReference"):
+		return &apisv1alpha1.ClusterProfileReferenceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterProfileSpec"):
+		return &apisv1alpha1.ClusterProfileSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterProfileStatus"):
+		return &apisv1alpha1.ClusterProfileStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterVersion"):
+		return &apisv1alpha1.ClusterVersionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PlacementDecision"):
+		return &apisv1alpha1.PlacementDecisionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Property"):
+		return &apisv1alpha1.PropertyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ResourceSummary"):
+		return &apisv1alpha1.ResourceSummaryApplyConfiguration{}
+
+		// Group=apis, Version=v1alpha2
+	case v1alpha2.SchemeGroupVersion.WithKind("AccessProvider"):
+		return &apisv1alpha2.AccessProviderApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("ClusterDecision"):
+		return &apisv1alpha2.ClusterDecisionApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("ClusterManager"):
+		return &apisv1alpha2.ClusterManagerApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("ClusterProfile"):
+		return &apisv1alpha2.ClusterProfileApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("ClusterProfileReference"):
+		return &apisv1alpha2.ClusterProfileReferenceApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("ClusterProfileSpec"):
+		return &apisv1alpha2.ClusterProfileSpecApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("ClusterProfileStatus"):
+		return &apisv1alpha2.ClusterProfileStatusApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("ClusterVersion"):
+		return &apisv1alpha2.ClusterVersionApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("PlacementDecision"):
+		return &apisv1alpha2.PlacementDecisionApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("PlacementDecisionSpec"):
+		return &apisv1alpha2.PlacementDecisionSpecApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("Property"):
+		return &apisv1alpha2.PropertyApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("ResourceSummary"):
+		return &apisv1alpha2.ResourceSummaryApplyConfiguration{}
+
+	}
+	return nil
+}
+
+func NewTypeConverter(scheme *runtime.Scheme) managedfields.TypeConverter {
+	return managedfields.NewSchemeTypeConverter(scheme, internal.Parser())
+}
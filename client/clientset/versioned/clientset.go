@@ -25,17 +25,20 @@ import (
 	rest "k8s.io/client-go/rest"
 	flowcontrol "k8s.io/client-go/util/flowcontrol"
 	apisv1alpha1 "sigs.k8s.io/cluster-inventory-api/client/clientset/versioned/typed/apis/v1alpha1"
+	apisv1alpha2 "sigs.k8s.io/cluster-inventory-api/client/clientset/versioned/typed/apis/v1alpha2"
 )
 
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	ApisV1alpha1() apisv1alpha1.ApisV1alpha1Interface
+	ApisV1alpha2() apisv1alpha2.ApisV1alpha2Interface
 }
 
 // Clientset contains the clients for groups.
 type Clientset struct {
 	*discovery.DiscoveryClient
 	apisV1alpha1 *apisv1alpha1.ApisV1alpha1Client
+	apisV1alpha2 *apisv1alpha2.ApisV1alpha2Client
 }
 
 // ApisV1alpha1 retrieves the ApisV1alpha1Client
@@ -43,6 +46,11 @@ func (c *Clientset) ApisV1alpha1() apisv1alpha1.ApisV1alpha1Interface {
 	return c.apisV1alpha1
 }
 
+// ApisV1alpha2 retrieves the ApisV1alpha2Client
+func (c *Clientset) ApisV1alpha2() apisv1alpha2.ApisV1alpha2Interface {
+	return c.apisV1alpha2
+}
+
 // Discovery retrieves the DiscoveryClient
 func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 	if c == nil {
@@ -91,6 +99,10 @@ func NewForConfigAndClient(c *rest.Config, httpClient *http.Client) (*Clientset,
 	if err != nil {
 		return nil, err
 	}
+	cs.apisV1alpha2, err = apisv1alpha2.NewForConfigAndClient(&configShallowCopy, httpClient)
+	if err != nil {
+		return nil, err
+	}
 
 	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfigAndClient(&configShallowCopy, httpClient)
 	if err != nil {
@@ -113,6 +125,7 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.apisV1alpha1 = apisv1alpha1.New(c)
+	cs.apisV1alpha2 = apisv1alpha2.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
 	return &cs
@@ -0,0 +1,123 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/testing"
+	"sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+	"sigs.k8s.io/cluster-inventory-api/apis/v1alpha2"
+)
+
+// AddFieldSelectorReactors makes c filter list and watch requests by their field
+// selector, as the API server does for metadata.name, metadata.namespace and the
+// selectable fields of the CRDs. Without it, the fake clientset ignores field
+// selectors and returns every object. Selectors on other fields are rejected
+// with a BadRequest error, as by the API server.
+func AddFieldSelectorReactors(c *Clientset) {
+	c.PrependReactor("list", "*", func(action testing.Action) (bool, runtime.Object, error) {
+		list, ok := action.(testing.ListActionImpl)
+		if !ok || emptySelector(list.GetListRestrictions().Fields) {
+			return false, nil, nil
+		}
+		selector := list.GetListRestrictions().Fields
+		obj, err := c.Tracker().List(list.GetResource(), list.GetKind(), list.GetNamespace())
+		if err != nil {
+			return true, nil, err
+		}
+		items, err := meta.ExtractList(obj)
+		if err != nil {
+			return true, nil, err
+		}
+		var matched []runtime.Object
+		for _, item := range items {
+			ok, err := matchFields(item, selector)
+			if err != nil {
+				return true, nil, err
+			}
+			if ok {
+				matched = append(matched, item)
+			}
+		}
+		if err := meta.SetList(obj, matched); err != nil {
+			return true, nil, err
+		}
+		return true, obj, nil
+	})
+
+	c.PrependWatchReactor("*", func(action testing.Action) (bool, watch.Interface, error) {
+		w, ok := action.(testing.WatchActionImpl)
+		if !ok || emptySelector(w.GetWatchRestrictions().Fields) {
+			return false, nil, nil
+		}
+		selector := w.GetWatchRestrictions().Fields
+		watcher, err := c.Tracker().Watch(w.GetResource(), w.GetNamespace(), w.ListOptions)
+		if err != nil {
+			return true, nil, err
+		}
+		return true, watch.Filter(watcher, func(e watch.Event) (watch.Event, bool) {
+			ok, err := matchFields(e.Object, selector)
+			return e, err == nil && ok
+		}), nil
+	})
+}
+
+func emptySelector(selector fields.Selector) bool {
+	return selector == nil || selector.Empty()
+}
+
+// matchFields reports whether obj matches selector. It returns an error if
+// selector requires a field that is not selectable for obj.
+func matchFields(obj runtime.Object, selector fields.Selector) (bool, error) {
+	set, err := selectableFields(obj)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range selector.Requirements() {
+		if !set.Has(r.Field) {
+			return false, apierrors.NewBadRequest(fmt.Sprintf("field label not supported: %s", r.Field))
+		}
+	}
+	return selector.Matches(set), nil
+}
+
+func selectableFields(obj runtime.Object) (fields.Set, error) {
+	switch o := obj.(type) {
+	case *v1alpha1.ClusterProfile:
+		return v1alpha1.ClusterProfileFields(o), nil
+	case *v1alpha1.PlacementDecision:
+		return v1alpha1.PlacementDecisionFields(o), nil
+	case *v1alpha2.ClusterProfile:
+		return v1alpha2.ClusterProfileFields(o), nil
+	case *v1alpha2.PlacementDecision:
+		return v1alpha2.PlacementDecisionFields(o), nil
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	return fields.Set{
+		"metadata.name":      accessor.GetName(),
+		"metadata.namespace": accessor.GetNamespace(),
+	}, nil
+}
@@ -0,0 +1,150 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+	"sigs.k8s.io/cluster-inventory-api/apis/v1alpha2"
+	informers "sigs.k8s.io/cluster-inventory-api/client/informers/externalversions/apis/v1alpha1"
+	informersv1alpha2 "sigs.k8s.io/cluster-inventory-api/client/informers/externalversions/apis/v1alpha2"
+	listers "sigs.k8s.io/cluster-inventory-api/client/listers/apis/v1alpha1"
+	listersv1alpha2 "sigs.k8s.io/cluster-inventory-api/client/listers/apis/v1alpha2"
+)
+
+func TestFake(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "Fake Clientset Suite")
+}
+
+func clusterProfile(name, manager string) *v1alpha1.ClusterProfile {
+	return &v1alpha1.ClusterProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "fleet"},
+		Spec: v1alpha1.ClusterProfileSpec{
+			ClusterManager: v1alpha1.ClusterManager{Name: manager},
+		},
+	}
+}
+
+func names(profiles []*v1alpha1.ClusterProfile) []string {
+	var ret []string
+	for _, p := range profiles {
+		ret = append(ret, p.Name)
+	}
+	return ret
+}
+
+var _ = ginkgo.Describe("AddFieldSelectorReactors", func() {
+	var client *Clientset
+	byManager := fields.OneTermEqualSelector(v1alpha1.ClusterProfileClusterManagerNameField, "ocm")
+
+	ginkgo.BeforeEach(func() {
+		client = NewSimpleClientset(clusterProfile("a", "ocm"), clusterProfile("b", "fleet-manager"))
+		AddFieldSelectorReactors(client)
+	})
+
+	ginkgo.It("should filter lists by selectable fields", func() {
+		list, err := client.ApisV1alpha1().ClusterProfiles("fleet").List(context.TODO(),
+			metav1.ListOptions{FieldSelector: byManager.String()})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(list.Items).To(gomega.HaveLen(1))
+		gomega.Expect(list.Items[0].Name).To(gomega.Equal("a"))
+
+		list, err = client.ApisV1alpha1().ClusterProfiles("fleet").List(context.TODO(), metav1.ListOptions{})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(list.Items).To(gomega.HaveLen(2))
+	})
+
+	ginkgo.It("should reject fields that are not selectable", func() {
+		_, err := client.ApisV1alpha1().ClusterProfiles("fleet").List(context.TODO(),
+			metav1.ListOptions{FieldSelector: "status.version.kubernetes=1.35"})
+		gomega.Expect(apierrors.IsBadRequest(err)).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("should filter watch events", func() {
+		w, err := client.ApisV1alpha1().ClusterProfiles("fleet").Watch(context.TODO(),
+			metav1.ListOptions{FieldSelector: byManager.String()})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		defer w.Stop()
+
+		for _, p := range []*v1alpha1.ClusterProfile{clusterProfile("c", "fleet-manager"), clusterProfile("d", "ocm")} {
+			_, err := client.ApisV1alpha1().ClusterProfiles("fleet").Create(context.TODO(), p, metav1.CreateOptions{})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		}
+		var event watch.Event
+		gomega.Eventually(w.ResultChan()).Should(gomega.Receive(&event))
+		gomega.Expect(event.Object.(*v1alpha1.ClusterProfile).Name).To(gomega.Equal("d"))
+		gomega.Consistently(w.ResultChan(), 100*time.Millisecond).ShouldNot(gomega.Receive())
+	})
+
+	ginkgo.It("should feed informers built with a field selector", func(ctx context.Context) {
+		informer := informers.NewClusterProfileInformerWithFieldSelector(client, "fleet", 0, cache.Indexers{}, byManager)
+		go informer.Run(ctx.Done())
+		gomega.Expect(cache.WaitForCacheSync(ctx.Done(), informer.HasSynced)).To(gomega.BeTrue())
+
+		lister := listers.NewClusterProfileLister(informer.GetIndexer())
+		profiles, err := lister.ClusterProfiles("fleet").ListByFields(fields.Everything())
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(names(profiles)).To(gomega.Equal([]string{"a"}))
+	})
+
+	ginkgo.It("should feed PlacementDecision informers built with a field selector", func(ctx context.Context) {
+		for _, scheduler := range []string{"default", "spread"} {
+			_, err := client.ApisV1alpha1().PlacementDecisions("fleet").Create(ctx, &v1alpha1.PlacementDecision{
+				ObjectMeta:    metav1.ObjectMeta{Name: scheduler, Namespace: "fleet"},
+				SchedulerName: scheduler,
+			}, metav1.CreateOptions{})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		}
+		informer := informers.NewPlacementDecisionInformerWithFieldSelector(client, "fleet", 0, cache.Indexers{},
+			fields.OneTermEqualSelector(v1alpha1.PlacementDecisionSchedulerNameField, "spread"))
+		go informer.Run(ctx.Done())
+		gomega.Expect(cache.WaitForCacheSync(ctx.Done(), informer.HasSynced)).To(gomega.BeTrue())
+
+		decisions, err := listers.NewPlacementDecisionLister(informer.GetIndexer()).List(labels.Everything())
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(decisions).To(gomega.ConsistOf(gomega.HaveField("Name", "spread")))
+	})
+
+	ginkgo.It("should feed v1alpha2 informers built with a field selector", func(ctx context.Context) {
+		_, err := client.ApisV1alpha2().ClusterProfiles("fleet").Create(ctx, &v1alpha2.ClusterProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "fleet"},
+			Spec:       v1alpha2.ClusterProfileSpec{ClusterManager: v1alpha2.ClusterManager{Name: "ocm"}},
+		}, metav1.CreateOptions{})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		informer := informersv1alpha2.NewClusterProfileInformerWithFieldSelector(client, "fleet", 0, cache.Indexers{},
+			fields.OneTermEqualSelector(v1alpha2.ClusterProfileClusterManagerNameField, "ocm"))
+		go informer.Run(ctx.Done())
+		gomega.Expect(cache.WaitForCacheSync(ctx.Done(), informer.HasSynced)).To(gomega.BeTrue())
+
+		profiles, err := listersv1alpha2.NewClusterProfileLister(informer.GetIndexer()).List(labels.Everything())
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(profiles).To(gomega.ConsistOf(gomega.HaveField("Name", "c")))
+	})
+})
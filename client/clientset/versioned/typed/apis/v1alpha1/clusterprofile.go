@@ -25,6 +25,7 @@ import (
 	watch "k8s.io/apimachinery/pkg/watch"
 	gentype "k8s.io/client-go/gentype"
 	apisv1alpha1 "sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+	applyconfigurationapisv1alpha1 "sigs.k8s.io/cluster-inventory-api/client/applyconfiguration/apis/v1alpha1"
 	scheme "sigs.k8s.io/cluster-inventory-api/client/clientset/versioned/scheme"
 )
 
@@ -46,18 +47,21 @@ type ClusterProfileInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*apisv1alpha1.ClusterProfileList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apisv1alpha1.ClusterProfile, err error)
+	Apply(ctx context.Context, clusterProfile *applyconfigurationapisv1alpha1.ClusterProfileApplyConfiguration, opts v1.ApplyOptions) (result *apisv1alpha1.ClusterProfile, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, clusterProfile *applyconfigurationapisv1alpha1.ClusterProfileApplyConfiguration, opts v1.ApplyOptions) (result *apisv1alpha1.ClusterProfile, err error)
 	ClusterProfileExpansion
 }
 
 // clusterProfiles implements ClusterProfileInterface
 type clusterProfiles struct {
-	*gentype.ClientWithList[*apisv1alpha1.ClusterProfile, *apisv1alpha1.ClusterProfileList]
+	*gentype.ClientWithListAndApply[*apisv1alpha1.ClusterProfile, *apisv1alpha1.ClusterProfileList, *applyconfigurationapisv1alpha1.ClusterProfileApplyConfiguration]
 }
 
 // newClusterProfiles returns a ClusterProfiles
 func newClusterProfiles(c *ApisV1alpha1Client, namespace string) *clusterProfiles {
 	return &clusterProfiles{
-		gentype.NewClientWithList[*apisv1alpha1.ClusterProfile, *apisv1alpha1.ClusterProfileList](
+		gentype.NewClientWithListAndApply[*apisv1alpha1.ClusterProfile, *apisv1alpha1.ClusterProfileList, *applyconfigurationapisv1alpha1.ClusterProfileApplyConfiguration](
 			"clusterprofiles",
 			c.RESTClient(),
 			scheme.ParameterCodec,
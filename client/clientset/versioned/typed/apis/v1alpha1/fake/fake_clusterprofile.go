@@ -20,18 +20,19 @@ package fake
 import (
 	gentype "k8s.io/client-go/gentype"
 	v1alpha1 "sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
-	apisv1alpha1 "sigs.k8s.io/cluster-inventory-api/client/clientset/versioned/typed/apis/v1alpha1"
+	apisv1alpha1 "sigs.k8s.io/cluster-inventory-api/client/applyconfiguration/apis/v1alpha1"
+	typedapisv1alpha1 "sigs.k8s.io/cluster-inventory-api/client/clientset/versioned/typed/apis/v1alpha1"
 )
 
 // fakeClusterProfiles implements ClusterProfileInterface
 type fakeClusterProfiles struct {
-	*gentype.FakeClientWithList[*v1alpha1.ClusterProfile, *v1alpha1.ClusterProfileList]
+	*gentype.FakeClientWithListAndApply[*v1alpha1.ClusterProfile, *v1alpha1.ClusterProfileList, *apisv1alpha1.ClusterProfileApplyConfiguration]
 	Fake *FakeApisV1alpha1
 }
 
-func newFakeClusterProfiles(fake *FakeApisV1alpha1, namespace string) apisv1alpha1.ClusterProfileInterface {
+func newFakeClusterProfiles(fake *FakeApisV1alpha1, namespace string) typedapisv1alpha1.ClusterProfileInterface {
 	return &fakeClusterProfiles{
-		gentype.NewFakeClientWithList[*v1alpha1.ClusterProfile, *v1alpha1.ClusterProfileList](
+		gentype.NewFakeClientWithListAndApply[*v1alpha1.ClusterProfile, *v1alpha1.ClusterProfileList, *apisv1alpha1.ClusterProfileApplyConfiguration](
 			fake.Fake,
 			namespace,
 			v1alpha1.SchemeGroupVersion.WithResource("clusterprofiles"),
@@ -20,18 +20,19 @@ package fake
 import (
 	gentype "k8s.io/client-go/gentype"
 	v1alpha1 "sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
-	apisv1alpha1 "sigs.k8s.io/cluster-inventory-api/client/clientset/versioned/typed/apis/v1alpha1"
+	apisv1alpha1 "sigs.k8s.io/cluster-inventory-api/client/applyconfiguration/apis/v1alpha1"
+	typedapisv1alpha1 "sigs.k8s.io/cluster-inventory-api/client/clientset/versioned/typed/apis/v1alpha1"
 )
 
 // fakePlacementDecisions implements PlacementDecisionInterface
 type fakePlacementDecisions struct {
-	*gentype.FakeClientWithList[*v1alpha1.PlacementDecision, *v1alpha1.PlacementDecisionList]
+	*gentype.FakeClientWithListAndApply[*v1alpha1.PlacementDecision, *v1alpha1.PlacementDecisionList, *apisv1alpha1.PlacementDecisionApplyConfiguration]
 	Fake *FakeApisV1alpha1
 }
 
-func newFakePlacementDecisions(fake *FakeApisV1alpha1, namespace string) apisv1alpha1.PlacementDecisionInterface {
+func newFakePlacementDecisions(fake *FakeApisV1alpha1, namespace string) typedapisv1alpha1.PlacementDecisionInterface {
 	return &fakePlacementDecisions{
-		gentype.NewFakeClientWithList[*v1alpha1.PlacementDecision, *v1alpha1.PlacementDecisionList](
+		gentype.NewFakeClientWithListAndApply[*v1alpha1.PlacementDecision, *v1alpha1.PlacementDecisionList, *apisv1alpha1.PlacementDecisionApplyConfiguration](
 			fake.Fake,
 			namespace,
 			v1alpha1.SchemeGroupVersion.WithResource("placementdecisions"),
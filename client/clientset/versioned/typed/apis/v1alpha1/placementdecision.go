@@ -25,6 +25,7 @@ import (
 	watch "k8s.io/apimachinery/pkg/watch"
 	gentype "k8s.io/client-go/gentype"
 	apisv1alpha1 "sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+	applyconfigurationapisv1alpha1 "sigs.k8s.io/cluster-inventory-api/client/applyconfiguration/apis/v1alpha1"
 	scheme "sigs.k8s.io/cluster-inventory-api/client/clientset/versioned/scheme"
 )
 
@@ -44,18 +45,19 @@ type PlacementDecisionInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*apisv1alpha1.PlacementDecisionList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apisv1alpha1.PlacementDecision, err error)
+	Apply(ctx context.Context, placementDecision *applyconfigurationapisv1alpha1.PlacementDecisionApplyConfiguration, opts v1.ApplyOptions) (result *apisv1alpha1.PlacementDecision, err error)
 	PlacementDecisionExpansion
 }
 
 // placementDecisions implements PlacementDecisionInterface
 type placementDecisions struct {
-	*gentype.ClientWithList[*apisv1alpha1.PlacementDecision, *apisv1alpha1.PlacementDecisionList]
+	*gentype.ClientWithListAndApply[*apisv1alpha1.PlacementDecision, *apisv1alpha1.PlacementDecisionList, *applyconfigurationapisv1alpha1.PlacementDecisionApplyConfiguration]
 }
 
 // newPlacementDecisions returns a PlacementDecisions
 func newPlacementDecisions(c *ApisV1alpha1Client, namespace string) *placementDecisions {
 	return &placementDecisions{
-		gentype.NewClientWithList[*apisv1alpha1.PlacementDecision, *apisv1alpha1.PlacementDecisionList](
+		gentype.NewClientWithListAndApply[*apisv1alpha1.PlacementDecision, *apisv1alpha1.PlacementDecisionList, *applyconfigurationapisv1alpha1.PlacementDecisionApplyConfiguration](
 			"placementdecisions",
 			c.RESTClient(),
 			scheme.ParameterCodec,
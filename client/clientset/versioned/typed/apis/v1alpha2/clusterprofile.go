@@ -0,0 +1,73 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	context "context"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+	apisv1alpha2 "sigs.k8s.io/cluster-inventory-api/apis/v1alpha2"
+	applyconfigurationapisv1alpha2 "sigs.k8s.io/cluster-inventory-api/client/applyconfiguration/apis/v1alpha2"
+	scheme "sigs.k8s.io/cluster-inventory-api/client/clientset/versioned/scheme"
+)
+
+// ClusterProfilesGetter has a method to return a ClusterProfileInterface.
+// A group's client should implement this interface.
+type ClusterProfilesGetter interface {
+	ClusterProfiles(namespace string) ClusterProfileInterface
+}
+
+// ClusterProfileInterface has methods to work with ClusterProfile resources.
+type ClusterProfileInterface interface {
+	Create(ctx context.Context, clusterProfile *apisv1alpha2.ClusterProfile, opts v1.CreateOptions) (*apisv1alpha2.ClusterProfile, error)
+	Update(ctx context.Context, clusterProfile *apisv1alpha2.ClusterProfile, opts v1.UpdateOptions) (*apisv1alpha2.ClusterProfile, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, clusterProfile *apisv1alpha2.ClusterProfile, opts v1.UpdateOptions) (*apisv1alpha2.ClusterProfile, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apisv1alpha2.ClusterProfile, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apisv1alpha2.ClusterProfileList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apisv1alpha2.ClusterProfile, err error)
+	Apply(ctx context.Context, clusterProfile *applyconfigurationapisv1alpha2.ClusterProfileApplyConfiguration, opts v1.ApplyOptions) (result *apisv1alpha2.ClusterProfile, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, clusterProfile *applyconfigurationapisv1alpha2.ClusterProfileApplyConfiguration, opts v1.ApplyOptions) (result *apisv1alpha2.ClusterProfile, err error)
+	ClusterProfileExpansion
+}
+
+// clusterProfiles implements ClusterProfileInterface
+type clusterProfiles struct {
+	*gentype.ClientWithListAndApply[*apisv1alpha2.ClusterProfile, *apisv1alpha2.ClusterProfileList, *applyconfigurationapisv1alpha2.ClusterProfileApplyConfiguration]
+}
+
+// newClusterProfiles returns a ClusterProfiles
+func newClusterProfiles(c *ApisV1alpha2Client, namespace string) *clusterProfiles {
+	return &clusterProfiles{
+		gentype.NewClientWithListAndApply[*apisv1alpha2.ClusterProfile, *apisv1alpha2.ClusterProfileList, *applyconfigurationapisv1alpha2.ClusterProfileApplyConfiguration](
+			"clusterprofiles",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *apisv1alpha2.ClusterProfile { return &apisv1alpha2.ClusterProfile{} },
+			func() *apisv1alpha2.ClusterProfileList { return &apisv1alpha2.ClusterProfileList{} },
+		),
+	}
+}
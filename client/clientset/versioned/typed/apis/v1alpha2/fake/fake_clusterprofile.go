@@ -0,0 +1,52 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	gentype "k8s.io/client-go/gentype"
+	v1alpha2 "sigs.k8s.io/cluster-inventory-api/apis/v1alpha2"
+	apisv1alpha2 "sigs.k8s.io/cluster-inventory-api/client/applyconfiguration/apis/v1alpha2"
+	typedapisv1alpha2 "sigs.k8s.io/cluster-inventory-api/client/clientset/versioned/typed/apis/v1alpha2"
+)
+
+// fakeClusterProfiles implements ClusterProfileInterface
+type fakeClusterProfiles struct {
+	*gentype.FakeClientWithListAndApply[*v1alpha2.ClusterProfile, *v1alpha2.ClusterProfileList, *apisv1alpha2.ClusterProfileApplyConfiguration]
+	Fake *FakeApisV1alpha2
+}
+
+func newFakeClusterProfiles(fake *FakeApisV1alpha2, namespace string) typedapisv1alpha2.ClusterProfileInterface {
+	return &fakeClusterProfiles{
+		gentype.NewFakeClientWithListAndApply[*v1alpha2.ClusterProfile, *v1alpha2.ClusterProfileList, *apisv1alpha2.ClusterProfileApplyConfiguration](
+			fake.Fake,
+			namespace,
+			v1alpha2.SchemeGroupVersion.WithResource("clusterprofiles"),
+			v1alpha2.SchemeGroupVersion.WithKind("ClusterProfile"),
+			func() *v1alpha2.ClusterProfile { return &v1alpha2.ClusterProfile{} },
+			func() *v1alpha2.ClusterProfileList { return &v1alpha2.ClusterProfileList{} },
+			func(dst, src *v1alpha2.ClusterProfileList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha2.ClusterProfileList) []*v1alpha2.ClusterProfile {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha2.ClusterProfileList, items []*v1alpha2.ClusterProfile) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
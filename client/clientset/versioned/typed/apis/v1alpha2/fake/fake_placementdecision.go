@@ -0,0 +1,52 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	gentype "k8s.io/client-go/gentype"
+	v1alpha2 "sigs.k8s.io/cluster-inventory-api/apis/v1alpha2"
+	apisv1alpha2 "sigs.k8s.io/cluster-inventory-api/client/applyconfiguration/apis/v1alpha2"
+	typedapisv1alpha2 "sigs.k8s.io/cluster-inventory-api/client/clientset/versioned/typed/apis/v1alpha2"
+)
+
+// fakePlacementDecisions implements PlacementDecisionInterface
+type fakePlacementDecisions struct {
+	*gentype.FakeClientWithListAndApply[*v1alpha2.PlacementDecision, *v1alpha2.PlacementDecisionList, *apisv1alpha2.PlacementDecisionApplyConfiguration]
+	Fake *FakeApisV1alpha2
+}
+
+func newFakePlacementDecisions(fake *FakeApisV1alpha2, namespace string) typedapisv1alpha2.PlacementDecisionInterface {
+	return &fakePlacementDecisions{
+		gentype.NewFakeClientWithListAndApply[*v1alpha2.PlacementDecision, *v1alpha2.PlacementDecisionList, *apisv1alpha2.PlacementDecisionApplyConfiguration](
+			fake.Fake,
+			namespace,
+			v1alpha2.SchemeGroupVersion.WithResource("placementdecisions"),
+			v1alpha2.SchemeGroupVersion.WithKind("PlacementDecision"),
+			func() *v1alpha2.PlacementDecision { return &v1alpha2.PlacementDecision{} },
+			func() *v1alpha2.PlacementDecisionList { return &v1alpha2.PlacementDecisionList{} },
+			func(dst, src *v1alpha2.PlacementDecisionList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha2.PlacementDecisionList) []*v1alpha2.PlacementDecision {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha2.PlacementDecisionList, items []*v1alpha2.PlacementDecision) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
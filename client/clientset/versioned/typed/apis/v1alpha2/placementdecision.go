@@ -0,0 +1,69 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	context "context"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+	apisv1alpha2 "sigs.k8s.io/cluster-inventory-api/apis/v1alpha2"
+	applyconfigurationapisv1alpha2 "sigs.k8s.io/cluster-inventory-api/client/applyconfiguration/apis/v1alpha2"
+	scheme "sigs.k8s.io/cluster-inventory-api/client/clientset/versioned/scheme"
+)
+
+// PlacementDecisionsGetter has a method to return a PlacementDecisionInterface.
+// A group's client should implement this interface.
+type PlacementDecisionsGetter interface {
+	PlacementDecisions(namespace string) PlacementDecisionInterface
+}
+
+// PlacementDecisionInterface has methods to work with PlacementDecision resources.
+type PlacementDecisionInterface interface {
+	Create(ctx context.Context, placementDecision *apisv1alpha2.PlacementDecision, opts v1.CreateOptions) (*apisv1alpha2.PlacementDecision, error)
+	Update(ctx context.Context, placementDecision *apisv1alpha2.PlacementDecision, opts v1.UpdateOptions) (*apisv1alpha2.PlacementDecision, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apisv1alpha2.PlacementDecision, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apisv1alpha2.PlacementDecisionList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apisv1alpha2.PlacementDecision, err error)
+	Apply(ctx context.Context, placementDecision *applyconfigurationapisv1alpha2.PlacementDecisionApplyConfiguration, opts v1.ApplyOptions) (result *apisv1alpha2.PlacementDecision, err error)
+	PlacementDecisionExpansion
+}
+
+// placementDecisions implements PlacementDecisionInterface
+type placementDecisions struct {
+	*gentype.ClientWithListAndApply[*apisv1alpha2.PlacementDecision, *apisv1alpha2.PlacementDecisionList, *applyconfigurationapisv1alpha2.PlacementDecisionApplyConfiguration]
+}
+
+// newPlacementDecisions returns a PlacementDecisions
+func newPlacementDecisions(c *ApisV1alpha2Client, namespace string) *placementDecisions {
+	return &placementDecisions{
+		gentype.NewClientWithListAndApply[*apisv1alpha2.PlacementDecision, *apisv1alpha2.PlacementDecisionList, *applyconfigurationapisv1alpha2.PlacementDecisionApplyConfiguration](
+			"placementdecisions",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *apisv1alpha2.PlacementDecision { return &apisv1alpha2.PlacementDecision{} },
+			func() *apisv1alpha2.PlacementDecisionList { return &apisv1alpha2.PlacementDecisionList{} },
+		),
+	}
+}
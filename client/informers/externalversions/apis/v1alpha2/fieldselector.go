@@ -0,0 +1,50 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	time "time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fields "k8s.io/apimachinery/pkg/fields"
+	cache "k8s.io/client-go/tools/cache"
+	versioned "sigs.k8s.io/cluster-inventory-api/client/clientset/versioned"
+	internalinterfaces "sigs.k8s.io/cluster-inventory-api/client/informers/externalversions/internalinterfaces"
+)
+
+// WithFieldSelector returns a TweakListOptionsFunc that restricts list and watch
+// requests to the objects matching selector. Because a factory applies its tweak
+// to the informers of every type, pass it to the New*Informer functions below
+// rather than to a shared factory when the selector names type-specific fields.
+func WithFieldSelector(selector fields.Selector) internalinterfaces.TweakListOptionsFunc {
+	return func(options *v1.ListOptions) {
+		options.FieldSelector = selector.String()
+	}
+}
+
+// NewClusterProfileInformerWithFieldSelector constructs a new informer for the
+// ClusterProfiles matching selector, such as
+// fields.OneTermEqualSelector(ClusterProfileClusterManagerNameField, name).
+func NewClusterProfileInformerWithFieldSelector(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, selector fields.Selector) cache.SharedIndexInformer {
+	return NewFilteredClusterProfileInformer(client, namespace, resyncPeriod, indexers, WithFieldSelector(selector))
+}
+
+// NewPlacementDecisionInformerWithFieldSelector constructs a new informer for the
+// PlacementDecisions matching selector.
+func NewPlacementDecisionInformerWithFieldSelector(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, selector fields.Selector) cache.SharedIndexInformer {
+	return NewFilteredPlacementDecisionInformer(client, namespace, resyncPeriod, indexers, WithFieldSelector(selector))
+}
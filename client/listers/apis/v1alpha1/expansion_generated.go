@@ -16,19 +16,3 @@ limitations under the License.
 // Code generated by lister-gen. DO NOT EDIT.
 
 package v1alpha1
-
-// ClusterProfileListerExpansion allows custom methods to be added to
-// ClusterProfileLister.
-type ClusterProfileListerExpansion interface{}
-
-// ClusterProfileNamespaceListerExpansion allows custom methods to be added to
-// ClusterProfileNamespaceLister.
-type ClusterProfileNamespaceListerExpansion interface{}
-
-// PlacementDecisionListerExpansion allows custom methods to be added to
-// PlacementDecisionLister.
-type PlacementDecisionListerExpansion interface{}
-
-// PlacementDecisionNamespaceListerExpansion allows custom methods to be added to
-// PlacementDecisionNamespaceLister.
-type PlacementDecisionNamespaceListerExpansion interface{}
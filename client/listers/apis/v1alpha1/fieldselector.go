@@ -0,0 +1,36 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	fields "k8s.io/apimachinery/pkg/fields"
+)
+
+// checkSelectable returns a BadRequest error, as the API server does, if
+// selector requires a field that is not in selectable. Otherwise such a
+// selector would silently match nothing.
+func checkSelectable(selector fields.Selector, selectable fields.Set) error {
+	for _, r := range selector.Requirements() {
+		if !selectable.Has(r.Field) {
+			return apierrors.NewBadRequest(fmt.Sprintf("field label not supported: %s", r.Field))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/tools/cache"
+
+	apisv1alpha1 "sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+)
+
+func TestListers(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "Listers Suite")
+}
+
+func newIndexer(objs ...metav1.Object) cache.Indexer {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, obj := range objs {
+		gomega.Expect(indexer.Add(obj)).To(gomega.Succeed())
+	}
+	return indexer
+}
+
+func clusterProfile(namespace, name, manager string) *apisv1alpha1.ClusterProfile {
+	return &apisv1alpha1.ClusterProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       apisv1alpha1.ClusterProfileSpec{ClusterManager: apisv1alpha1.ClusterManager{Name: manager}},
+	}
+}
+
+func placementDecision(namespace, name, scheduler string) *apisv1alpha1.PlacementDecision {
+	return &apisv1alpha1.PlacementDecision{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}, SchedulerName: scheduler,
+	}
+}
+
+var _ = ginkgo.Describe("ListByFields", func() {
+	unsupported := fields.OneTermEqualSelector("status.version.kubernetes", "1.35")
+
+	ginkgo.It("should filter ClusterProfiles by selectable fields", func() {
+		lister := NewClusterProfileLister(newIndexer(
+			clusterProfile("fleet", "a", "ocm"),
+			clusterProfile("fleet", "b", "fleet-manager"),
+			clusterProfile("other", "c", "fleet-manager"),
+		))
+
+		byManager := fields.OneTermEqualSelector(apisv1alpha1.ClusterProfileClusterManagerNameField, "fleet-manager")
+		profiles, err := lister.ListByFields(byManager)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(profiles).To(gomega.ConsistOf(
+			gomega.HaveField("Name", "b"), gomega.HaveField("Name", "c")))
+
+		profiles, err = lister.ClusterProfiles("fleet").ListByFields(byManager)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(profiles).To(gomega.ConsistOf(gomega.HaveField("Name", "b")))
+
+		profiles, err = lister.ListByFields(fields.OneTermNotEqualSelector("metadata.namespace", "fleet"))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(profiles).To(gomega.ConsistOf(gomega.HaveField("Name", "c")))
+	})
+
+	ginkgo.It("should filter PlacementDecisions by selectable fields", func() {
+		lister := NewPlacementDecisionLister(newIndexer(
+			placementDecision("fleet", "a", "default"),
+			placementDecision("fleet", "b", "spread"),
+		))
+
+		bySchedulerName := fields.OneTermEqualSelector(apisv1alpha1.PlacementDecisionSchedulerNameField, "spread")
+		decisions, err := lister.ListByFields(bySchedulerName)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(decisions).To(gomega.ConsistOf(gomega.HaveField("Name", "b")))
+
+		decisions, err = lister.PlacementDecisions("other").ListByFields(fields.Everything())
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(decisions).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should reject fields that are not selectable, even without objects", func() {
+		profiles := NewClusterProfileLister(newIndexer())
+		_, err := profiles.ListByFields(unsupported)
+		gomega.Expect(apierrors.IsBadRequest(err)).To(gomega.BeTrue())
+		_, err = profiles.ClusterProfiles("fleet").ListByFields(unsupported)
+		gomega.Expect(apierrors.IsBadRequest(err)).To(gomega.BeTrue())
+
+		decisions := NewPlacementDecisionLister(newIndexer(placementDecision("fleet", "a", "default")))
+		_, err = decisions.ListByFields(unsupported)
+		gomega.Expect(apierrors.IsBadRequest(err)).To(gomega.BeTrue())
+		_, err = decisions.PlacementDecisions("fleet").ListByFields(unsupported)
+		gomega.Expect(apierrors.IsBadRequest(err)).To(gomega.BeTrue())
+	})
+})
@@ -0,0 +1,75 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	fields "k8s.io/apimachinery/pkg/fields"
+	labels "k8s.io/apimachinery/pkg/labels"
+	apisv1alpha1 "sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+)
+
+// PlacementDecisionListerExpansion allows custom methods to be added to
+// PlacementDecisionLister.
+type PlacementDecisionListerExpansion interface {
+	// ListByFields lists all PlacementDecisions in the indexer matching selector.
+	// It returns a BadRequest error if selector requires a field that is not
+	// selectable. Objects returned here must be treated as read-only.
+	ListByFields(selector fields.Selector) (ret []*apisv1alpha1.PlacementDecision, err error)
+}
+
+// PlacementDecisionNamespaceListerExpansion allows custom methods to be added to
+// PlacementDecisionNamespaceLister.
+type PlacementDecisionNamespaceListerExpansion interface {
+	// ListByFields lists all PlacementDecisions in the indexer for a given namespace matching selector.
+	// It returns a BadRequest error if selector requires a field that is not
+	// selectable. Objects returned here must be treated as read-only.
+	ListByFields(selector fields.Selector) (ret []*apisv1alpha1.PlacementDecision, err error)
+}
+
+// ListByFields lists all PlacementDecisions in the indexer matching selector.
+func (s *placementDecisionLister) ListByFields(selector fields.Selector) ([]*apisv1alpha1.PlacementDecision, error) {
+	if err := checkSelectable(selector, apisv1alpha1.PlacementDecisionFields(&apisv1alpha1.PlacementDecision{})); err != nil {
+		return nil, err
+	}
+	items, err := s.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return filterPlacementDecisions(items, selector), nil
+}
+
+// ListByFields lists all PlacementDecisions in the indexer for a given namespace matching selector.
+func (s placementDecisionNamespaceLister) ListByFields(selector fields.Selector) ([]*apisv1alpha1.PlacementDecision, error) {
+	if err := checkSelectable(selector, apisv1alpha1.PlacementDecisionFields(&apisv1alpha1.PlacementDecision{})); err != nil {
+		return nil, err
+	}
+	items, err := s.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return filterPlacementDecisions(items, selector), nil
+}
+
+func filterPlacementDecisions(items []*apisv1alpha1.PlacementDecision, selector fields.Selector) []*apisv1alpha1.PlacementDecision {
+	var ret []*apisv1alpha1.PlacementDecision
+	for _, item := range items {
+		if selector.Matches(apisv1alpha1.PlacementDecisionFields(item)) {
+			ret = append(ret, item)
+		}
+	}
+	return ret
+}
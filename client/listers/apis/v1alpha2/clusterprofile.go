@@ -0,0 +1,69 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+	apisv1alpha2 "sigs.k8s.io/cluster-inventory-api/apis/v1alpha2"
+)
+
+// ClusterProfileLister helps list ClusterProfiles.
+// All objects returned here must be treated as read-only.
+type ClusterProfileLister interface {
+	// List lists all ClusterProfiles in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apisv1alpha2.ClusterProfile, err error)
+	// ClusterProfiles returns an object that can list and get ClusterProfiles.
+	ClusterProfiles(namespace string) ClusterProfileNamespaceLister
+	ClusterProfileListerExpansion
+}
+
+// clusterProfileLister implements the ClusterProfileLister interface.
+type clusterProfileLister struct {
+	listers.ResourceIndexer[*apisv1alpha2.ClusterProfile]
+}
+
+// NewClusterProfileLister returns a new ClusterProfileLister.
+func NewClusterProfileLister(indexer cache.Indexer) ClusterProfileLister {
+	return &clusterProfileLister{listers.New[*apisv1alpha2.ClusterProfile](indexer, apisv1alpha2.Resource("clusterprofile"))}
+}
+
+// ClusterProfiles returns an object that can list and get ClusterProfiles.
+func (s *clusterProfileLister) ClusterProfiles(namespace string) ClusterProfileNamespaceLister {
+	return clusterProfileNamespaceLister{listers.NewNamespaced[*apisv1alpha2.ClusterProfile](s.ResourceIndexer, namespace)}
+}
+
+// ClusterProfileNamespaceLister helps list and get ClusterProfiles.
+// All objects returned here must be treated as read-only.
+type ClusterProfileNamespaceLister interface {
+	// List lists all ClusterProfiles in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apisv1alpha2.ClusterProfile, err error)
+	// Get retrieves the ClusterProfile from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*apisv1alpha2.ClusterProfile, error)
+	ClusterProfileNamespaceListerExpansion
+}
+
+// clusterProfileNamespaceLister implements the ClusterProfileNamespaceLister
+// interface.
+type clusterProfileNamespaceLister struct {
+	listers.ResourceIndexer[*apisv1alpha2.ClusterProfile]
+}
@@ -0,0 +1,69 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+	apisv1alpha2 "sigs.k8s.io/cluster-inventory-api/apis/v1alpha2"
+)
+
+// PlacementDecisionLister helps list PlacementDecisions.
+// All objects returned here must be treated as read-only.
+type PlacementDecisionLister interface {
+	// List lists all PlacementDecisions in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apisv1alpha2.PlacementDecision, err error)
+	// PlacementDecisions returns an object that can list and get PlacementDecisions.
+	PlacementDecisions(namespace string) PlacementDecisionNamespaceLister
+	PlacementDecisionListerExpansion
+}
+
+// placementDecisionLister implements the PlacementDecisionLister interface.
+type placementDecisionLister struct {
+	listers.ResourceIndexer[*apisv1alpha2.PlacementDecision]
+}
+
+// NewPlacementDecisionLister returns a new PlacementDecisionLister.
+func NewPlacementDecisionLister(indexer cache.Indexer) PlacementDecisionLister {
+	return &placementDecisionLister{listers.New[*apisv1alpha2.PlacementDecision](indexer, apisv1alpha2.Resource("placementdecision"))}
+}
+
+// PlacementDecisions returns an object that can list and get PlacementDecisions.
+func (s *placementDecisionLister) PlacementDecisions(namespace string) PlacementDecisionNamespaceLister {
+	return placementDecisionNamespaceLister{listers.NewNamespaced[*apisv1alpha2.PlacementDecision](s.ResourceIndexer, namespace)}
+}
+
+// PlacementDecisionNamespaceLister helps list and get PlacementDecisions.
+// All objects returned here must be treated as read-only.
+type PlacementDecisionNamespaceLister interface {
+	// List lists all PlacementDecisions in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apisv1alpha2.PlacementDecision, err error)
+	// Get retrieves the PlacementDecision from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*apisv1alpha2.PlacementDecision, error)
+	PlacementDecisionNamespaceListerExpansion
+}
+
+// placementDecisionNamespaceLister implements the PlacementDecisionNamespaceLister
+// interface.
+type placementDecisionNamespaceLister struct {
+	listers.ResourceIndexer[*apisv1alpha2.PlacementDecision]
+}
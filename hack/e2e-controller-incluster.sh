@@ -56,6 +56,18 @@ echo "--- Patch ClusterProfile spoke-1 so spoke server is reachable from hub (sp
 kubectl --context kind-hub patch clusterprofile spoke-1 -n "${PROFILE_NS}" --type=json --subresource=status \
 	-p '[{"op":"replace","path":"/status/accessProviders/0/cluster/server","value":"https://spoke-control-plane:6443"}]'
 
+if [[ "${PLUGIN_NAME}" == "kubeconfig-secretreader" ]]; then
+	# The plugin refuses kubeconfigs whose server differs from the ClusterProfile's.
+	echo "--- Patch kubeconfig in Secret spoke-1-kubeconfig to the same spoke server"
+	KUBECONFIG_YAML=$(kubectl --context kind-hub -n "${DEPLOY_NS}" get secret spoke-1-kubeconfig \
+		-o jsonpath='{.data.value}' | base64 -d \
+		| sed -E 's#^( *server: ).*#\1https://spoke-control-plane:6443#')
+	kubectl --context kind-hub create secret generic spoke-1-kubeconfig \
+		--namespace "${DEPLOY_NS}" \
+		--from-literal=value="${KUBECONFIG_YAML}" \
+		--dry-run=client -o yaml | kubectl --context kind-hub apply -f -
+fi
+
 echo "--- Apply RBAC for controller-example on hub"
 kubectl --context kind-hub apply -f - <<EOF
 apiVersion: v1
@@ -41,6 +41,7 @@ kube::codegen::gen_helpers \
 
 kube::codegen::gen_client \
     --with-watch \
+    --with-applyconfig \
     --output-dir "${SCRIPT_ROOT}/client" \
     --output-pkg "${THIS_PKG}/client" \
     --boilerplate "${SCRIPT_ROOT}/hack/boilerplate.go.txt" \
@@ -42,6 +42,7 @@ import (
 	clientcmdlatest "k8s.io/client-go/tools/clientcmd/api/latest"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+	"sigs.k8s.io/cluster-inventory-api/apis/v1alpha1/helpers"
 )
 
 const (
@@ -212,25 +213,17 @@ func (c *Config) getExecConfigAndFlagsFromConfig(
 func (c *Config) getClusterAccessorFromClusterProfile(
 	cluster *v1alpha1.ClusterProfile,
 ) *v1alpha1.AccessProvider {
-	accessProviderTypes := map[string]*v1alpha1.AccessProvider{}
-
-	// to keep backward compatibility, we first check the CredentialProviders field
 	for _, accessProvider := range cluster.Status.CredentialProviders {
-		accessProviderTypes[accessProvider.Name] = accessProvider.DeepCopy()
 		klog.Warningf(
 			"ClusterProfile %q uses deprecated field CredentialProviders %q; please migrate to AccessProviders",
 			cluster.Name, accessProvider.Name,
 		)
 	}
 
-	for _, accessProvider := range cluster.Status.AccessProviders {
-		accessProviderTypes[accessProvider.Name] = accessProvider.DeepCopy()
-	}
-
 	// we return the first access provider that the Config supports.
 	for _, providerType := range c.Providers {
-		if accessor, found := accessProviderTypes[providerType.Name]; found {
-			return accessor
+		if accessor := helpers.FindAccessProvider(cluster, providerType.Name); accessor != nil {
+			return accessor.DeepCopy()
 		}
 	}
 	return nil
@@ -0,0 +1,106 @@
+package credentialplugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+)
+
+// ChainProviderName is the name of the chained credential provider.
+const ChainProviderName = "chain"
+
+// ChainEntry is a single step of a chain, as described in
+// ExecCredential.Spec.Cluster.Config.
+type ChainEntry struct {
+	// Provider is the Name() of the Provider to invoke.
+	Provider string `json:"provider"`
+	// Config, if set, replaces ExecCredential.Spec.Cluster.Config for this step.
+	// If omitted, the step receives an empty Config.
+	Config *runtime.RawExtension `json:"config,omitempty"`
+}
+
+// ChainConfig is the expected shape of ExecCredential.Spec.Cluster.Config
+// for the chained provider.
+type ChainConfig struct {
+	// Chain lists the providers to try, in order.
+	Chain []ChainEntry `json:"chain"`
+}
+
+// Chain is a Provider that tries a list of Providers in order and returns the
+// status of the first one that succeeds. If every provider fails, the errors of
+// all attempts are returned together.
+//
+// The order of the attempts and the per-provider config are read from
+// ExecCredential.Spec.Cluster.Config (see ChainConfig), so that a ClusterProfile
+// can describe its fallbacks. Providers only describes which implementations are
+// available to the chain; a provider that is not listed in the Config is never
+// invoked.
+type Chain struct {
+	// Providers are the providers available to the chain, looked up by Name().
+	Providers []Provider
+}
+
+// NewChain constructs a Chain from the given providers.
+func NewChain(providers ...Provider) *Chain {
+	return &Chain{Providers: providers}
+}
+
+func (Chain) Name() string { return ChainProviderName }
+
+func (c Chain) GetToken(
+	ctx context.Context,
+	info clientauthenticationv1.ExecCredential,
+) (clientauthenticationv1.ExecCredentialStatus, error) {
+	if info.Spec.Cluster == nil || len(info.Spec.Cluster.Config.Raw) == 0 {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("missing ExecCredential.Spec.Cluster.Config")
+	}
+	var cfg ChainConfig
+	if err := json.Unmarshal(info.Spec.Cluster.Config.Raw, &cfg); err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{},
+			fmt.Errorf("invalid ExecCredential.Spec.Cluster.Config: %w", err)
+	}
+	if len(cfg.Chain) == 0 {
+		return clientauthenticationv1.ExecCredentialStatus{},
+			fmt.Errorf("missing chain in ExecCredential.Spec.Cluster.Config")
+	}
+
+	providers := make(map[string]Provider, len(c.Providers))
+	for _, p := range c.Providers {
+		providers[p.Name()] = p
+	}
+
+	var errs []error
+	for i, entry := range cfg.Chain {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		p, ok := providers[entry.Provider]
+		if !ok {
+			errs = append(errs, fmt.Errorf("chain[%d]: provider %q is not available", i, entry.Provider))
+			continue
+		}
+
+		// Each step only sees its own config; the rest of the cluster info is shared.
+		stepInfo := *info.DeepCopy()
+		stepInfo.Spec.Cluster.Config = runtime.RawExtension{}
+		if entry.Config != nil {
+			stepInfo.Spec.Cluster.Config = *entry.Config.DeepCopy()
+		}
+
+		status, err := p.GetToken(ctx, stepInfo)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("chain[%d] %s: %w", i, entry.Provider, err))
+			continue
+		}
+		return status, nil
+	}
+
+	return clientauthenticationv1.ExecCredentialStatus{},
+		fmt.Errorf("all providers in chain failed: %w", errors.Join(errs...))
+}
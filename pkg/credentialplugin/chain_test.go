@@ -0,0 +1,119 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentialplugin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+)
+
+func TestCredentialPlugin(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "Credential Plugin Suite")
+}
+
+// fakeProvider records the config it was called with and returns a fixed result.
+type fakeProvider struct {
+	name   string
+	token  string
+	err    error
+	called *[]string
+}
+
+func (f fakeProvider) Name() string { return f.name }
+
+func (f fakeProvider) GetToken(
+	_ context.Context,
+	in clientauthenticationv1.ExecCredential,
+) (clientauthenticationv1.ExecCredentialStatus, error) {
+	*f.called = append(*f.called, f.name+":"+string(in.Spec.Cluster.Config.Raw))
+	if f.err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, f.err
+	}
+	return clientauthenticationv1.ExecCredentialStatus{Token: f.token}, nil
+}
+
+func execCredentialWithConfig(raw string) clientauthenticationv1.ExecCredential {
+	return clientauthenticationv1.ExecCredential{
+		Spec: clientauthenticationv1.ExecCredentialSpec{
+			Cluster: &clientauthenticationv1.Cluster{
+				Server: "https://spoke.example.com",
+				Config: runtime.RawExtension{Raw: []byte(raw)},
+			},
+		},
+	}
+}
+
+var _ = ginkgo.Describe("Chain", func() {
+	var called []string
+
+	ginkgo.BeforeEach(func() {
+		called = nil
+	})
+
+	ginkgo.It("should return the first successful provider and pass each its own config", func() {
+		chain := NewChain(
+			fakeProvider{name: "wi", err: errors.New("no identity"), called: &called},
+			fakeProvider{name: "secret", token: "t-secret", called: &called},
+			fakeProvider{name: "unused", token: "t-unused", called: &called},
+		)
+
+		status, err := chain.GetToken(context.Background(), execCredentialWithConfig(`{"chain":[
+			{"provider":"wi","config":{"audience":"a"}},
+			{"provider":"secret","config":{"clusterName":"spoke-1"}},
+			{"provider":"unused"}
+		]}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(status.Token).To(gomega.Equal("t-secret"))
+		gomega.Expect(called).To(gomega.Equal([]string{
+			`wi:{"audience":"a"}`,
+			`secret:{"clusterName":"spoke-1"}`,
+		}))
+	})
+
+	ginkgo.It("should collect the errors of every step when all providers fail", func() {
+		chain := NewChain(
+			fakeProvider{name: "a", err: errors.New("boom-a"), called: &called},
+			fakeProvider{name: "b", err: errors.New("boom-b"), called: &called},
+		)
+
+		_, err := chain.GetToken(context.Background(), execCredentialWithConfig(
+			`{"chain":[{"provider":"a"},{"provider":"missing"},{"provider":"b"}]}`,
+		))
+		gomega.Expect(err).To(gomega.HaveOccurred())
+		gomega.Expect(err.Error()).To(gomega.ContainSubstring("boom-a"))
+		gomega.Expect(err.Error()).To(gomega.ContainSubstring(`provider "missing" is not available`))
+		gomega.Expect(err.Error()).To(gomega.ContainSubstring("boom-b"))
+		gomega.Expect(called).To(gomega.HaveLen(2))
+	})
+
+	ginkgo.It("should reject a config without a chain", func() {
+		_, err := NewChain().GetToken(context.Background(), execCredentialWithConfig(`{}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("missing chain")))
+	})
+
+	ginkgo.It("should reject a missing config", func() {
+		_, err := NewChain().GetToken(context.Background(), clientauthenticationv1.ExecCredential{})
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("missing ExecCredential.Spec.Cluster.Config")))
+	})
+})
@@ -65,6 +65,14 @@ func Run(p Provider) {
 		os.Exit(1)
 	}
 
+	if err := writeExecCredential(status); err != nil {
+		errPrintf(plugin, "%v", err)
+		os.Exit(1)
+	}
+}
+
+// writeExecCredential writes an ExecCredential with the given status to stdout
+func writeExecCredential(status clientauthenticationv1.ExecCredentialStatus) error {
 	// Build ExecCredential JSON from returned status
 	ec := &clientauthenticationv1.ExecCredential{
 		TypeMeta: metav1.TypeMeta{
@@ -75,14 +83,13 @@ func Run(p Provider) {
 	}
 	b, err := json.Marshal(ec)
 	if err != nil {
-		errPrintf(plugin, "failed to marshal ExecCredential: %v", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to marshal ExecCredential: %w", err)
 	}
 
 	w := bufio.NewWriter(os.Stdout)
 	_, _ = w.Write(b)
 	_ = w.WriteByte('\n')
-	_ = w.Flush()
+	return w.Flush()
 }
 
 // BuildExecCredentialJSON constructs a minimal ExecCredential JSON
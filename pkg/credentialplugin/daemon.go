@@ -0,0 +1,234 @@
+package credentialplugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+)
+
+const (
+	// daemonDialTimeout bounds how long the shim waits to connect to the daemon
+	// before falling back to direct mode.
+	daemonDialTimeout = time.Second
+	// daemonRequestTimeout bounds a single request served by the daemon.
+	daemonRequestTimeout = 30 * time.Second
+)
+
+// ErrDaemonUnavailable is returned by RequestToken when no daemon answers on the socket.
+var ErrDaemonUnavailable = errors.New("credential daemon is unavailable")
+
+// daemonRequest is sent by the shim to the daemon, one per connection.
+type daemonRequest struct {
+	// Provider is the Name() of the provider the shim expects to talk to.
+	Provider       string                                `json:"provider"`
+	ExecCredential clientauthenticationv1.ExecCredential `json:"execCredential"`
+}
+
+// daemonResponse is the daemon's answer to a daemonRequest.
+type daemonResponse struct {
+	Status *clientauthenticationv1.ExecCredentialStatus `json:"status,omitempty"`
+	Error  string                                       `json:"error,omitempty"`
+}
+
+// Serve listens on the Unix socket at socketPath and answers GetToken requests
+// with p until ctx is done. A stale socket file is replaced, but Serve fails if
+// another daemon answers on socketPath. The socket is only accessible to the
+// user running the daemon.
+func Serve(ctx context.Context, socketPath string, p Provider) error {
+	l, err := listenPrivate(ctx, socketPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = l.Close()
+		_ = os.Remove(socketPath)
+	}()
+
+	stop := context.AfterFunc(ctx, func() { _ = l.Close() })
+	defer stop()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+		go serveConn(ctx, conn, p)
+	}
+}
+
+// listenPrivate listens on a Unix socket at socketPath that only its owner can
+// connect to. The socket is created with the umask, so it is created in a new
+// directory that only the owner can enter, restricted there, and then linked to
+// socketPath. A link, unlike a rename, fails if socketPath exists, so the socket
+// of a running daemon is never replaced; only a stale one is removed.
+func listenPrivate(ctx context.Context, socketPath string) (*net.UnixListener, error) {
+	dir, err := os.MkdirTemp(filepath.Dir(socketPath), ".sock")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	tmpPath := filepath.Join(dir, "s")
+	var lc net.ListenConfig
+	l, err := lc.Listen(ctx, "unix", tmpPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %q: %w", socketPath, err)
+	}
+	ul := l.(*net.UnixListener)
+	// tmpPath is removed with dir; Serve removes socketPath.
+	ul.SetUnlinkOnClose(false)
+	if err := os.Chmod(tmpPath, 0o600); err != nil {
+		_ = ul.Close()
+		return nil, fmt.Errorf("failed to restrict permissions of %q: %w", socketPath, err)
+	}
+	err = os.Link(tmpPath, socketPath)
+	if errors.Is(err, fs.ErrExist) {
+		if err := removeStaleSocket(ctx, socketPath); err != nil {
+			_ = ul.Close()
+			return nil, err
+		}
+		err = os.Link(tmpPath, socketPath)
+	}
+	if err != nil {
+		_ = ul.Close()
+		return nil, fmt.Errorf("failed to move socket to %q: %w", socketPath, err)
+	}
+	return ul, nil
+}
+
+// removeStaleSocket removes socketPath unless a daemon still answers on it.
+func removeStaleSocket(ctx context.Context, socketPath string) error {
+	dialer := net.Dialer{Timeout: daemonDialTimeout}
+	if conn, err := dialer.DialContext(ctx, "unix", socketPath); err == nil {
+		_ = conn.Close()
+		return fmt.Errorf("a daemon is already serving %q", socketPath)
+	}
+	if err := os.Remove(socketPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove stale socket %q: %w", socketPath, err)
+	}
+	return nil
+}
+
+// serveConn answers the single request sent on conn.
+func serveConn(ctx context.Context, conn net.Conn, p Provider) {
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(daemonRequestTimeout))
+
+	ctx, cancel := context.WithTimeout(ctx, daemonRequestTimeout)
+	defer cancel()
+
+	var resp daemonResponse
+	var req daemonRequest
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		resp.Error = fmt.Sprintf("invalid request: %v", err)
+	} else if req.Provider != p.Name() {
+		resp.Error = fmt.Sprintf("daemon serves provider %q, not %q", p.Name(), req.Provider)
+	} else if status, err := p.GetToken(ctx, req.ExecCredential); err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Status = &status
+	}
+	_ = json.NewEncoder(conn).Encode(resp)
+}
+
+// RequestToken forwards info to the daemon serving provider on socketPath and
+// returns its answer. If the daemon cannot be reached, the returned error wraps
+// ErrDaemonUnavailable.
+func RequestToken(
+	ctx context.Context,
+	socketPath string,
+	provider string,
+	info clientauthenticationv1.ExecCredential,
+) (clientauthenticationv1.ExecCredentialStatus, error) {
+	d := net.Dialer{Timeout: daemonDialTimeout}
+	conn, err := d.DialContext(ctx, "unix", socketPath)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("%w: %v", ErrDaemonUnavailable, err)
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(daemonRequestTimeout))
+
+	if err := json.NewEncoder(conn).Encode(daemonRequest{Provider: provider, ExecCredential: info}); err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("%w: %v", ErrDaemonUnavailable, err)
+	}
+	var resp daemonResponse
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("%w: %v", ErrDaemonUnavailable, err)
+	}
+	if resp.Error != "" {
+		return clientauthenticationv1.ExecCredentialStatus{}, errors.New(resp.Error)
+	}
+	if resp.Status == nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, errors.New("credential daemon returned no status")
+	}
+	return *resp.Status, nil
+}
+
+// RunDaemon is the entrypoint of provider-specific binaries running as a
+// daemon. It serves p on socketPath until SIGINT or SIGTERM.
+func RunDaemon(p Provider, socketPath string) {
+	plugin := strings.TrimSpace(p.Name())
+	if plugin == "" {
+		fmt.Fprintln(os.Stderr, "[credentialplugin] provider Name() returned empty string; this is not allowed")
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := Serve(ctx, socketPath, p)
+	cancel()
+	if err != nil {
+		errPrintf(plugin, "%v", err)
+		os.Exit(1)
+	}
+}
+
+// RunShim is the entrypoint used by provider-specific binaries that may be
+// backed by a daemon. It forwards KUBERNETES_EXEC_INFO to the daemon serving
+// plugin on socketPath, and falls back to calling the Provider returned by
+// newProvider directly when the daemon is not running.
+func RunShim(plugin, socketPath string, newProvider func() (Provider, error)) {
+	plugin = strings.TrimSpace(plugin)
+	if plugin == "" {
+		fmt.Fprintln(os.Stderr, "[credentialplugin] plugin name is empty; this is not allowed")
+		os.Exit(1)
+	}
+
+	info, err := readExecInfo()
+	if err != nil {
+		errPrintf(plugin, "%v", err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	status, err := RequestToken(ctx, socketPath, plugin, *info)
+	if errors.Is(err, ErrDaemonUnavailable) {
+		var p Provider
+		p, err = newProvider()
+		if err == nil {
+			status, err = p.GetToken(ctx, *info)
+		}
+	}
+	if err != nil {
+		errPrintf(plugin, "%v", err)
+		os.Exit(1)
+	}
+
+	if err := writeExecCredential(status); err != nil {
+		errPrintf(plugin, "%v", err)
+		os.Exit(1)
+	}
+}
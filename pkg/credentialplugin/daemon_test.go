@@ -0,0 +1,202 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentialplugin
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var _ = ginkgo.Describe("Daemon", func() {
+	var (
+		socketPath string
+		called     []string
+		cancel     context.CancelFunc
+		done       chan error
+	)
+
+	ginkgo.BeforeEach(func() {
+		dir, err := os.MkdirTemp("", "cp-daemon")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		ginkgo.DeferCleanup(os.RemoveAll, dir)
+		socketPath = filepath.Join(dir, "daemon.sock")
+		called = nil
+
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		done = make(chan error, 1)
+		p := fakeProvider{name: "fake", token: "t-daemon", called: &called}
+		go func() { done <- Serve(ctx, socketPath, p) }()
+		gomega.Eventually(func() error {
+			_, err := os.Stat(socketPath)
+			return err
+		}).Should(gomega.Succeed())
+	})
+
+	ginkgo.AfterEach(func() {
+		cancel()
+		gomega.Eventually(done).Should(gomega.Receive(gomega.BeNil()))
+	})
+
+	ginkgo.It("should serve GetToken requests over the socket", func() {
+		status, err := RequestToken(context.Background(), socketPath, "fake", execCredentialWithConfig(`{"a":1}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(status.Token).To(gomega.Equal("t-daemon"))
+		gomega.Expect(called).To(gomega.Equal([]string{`fake:{"a":1}`}))
+	})
+
+	ginkgo.It("should restrict the socket to its owner", func() {
+		fi, err := os.Stat(socketPath)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(fi.Mode().Perm()).To(gomega.Equal(os.FileMode(0o600)))
+	})
+
+	ginkgo.It("should not leave the directory it created the socket in", func() {
+		entries, err := os.ReadDir(filepath.Dir(socketPath))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(entries).To(gomega.ConsistOf(gomega.HaveField("Name()", "daemon.sock")))
+	})
+
+	ginkgo.It("should refuse requests for another provider", func() {
+		_, err := RequestToken(context.Background(), socketPath, "other", execCredentialWithConfig(`{}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`not "other"`)))
+		gomega.Expect(errors.Is(err, ErrDaemonUnavailable)).To(gomega.BeFalse())
+	})
+
+	ginkgo.It("should not replace the socket of a running daemon", func() {
+		p := fakeProvider{name: "fake", token: "t-second", called: &called}
+		err := Serve(context.Background(), socketPath, p)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("a daemon is already serving")))
+
+		status, err := RequestToken(context.Background(), socketPath, "fake", execCredentialWithConfig(`{}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(status.Token).To(gomega.Equal("t-daemon"))
+	})
+
+	ginkgo.It("should replace a stale socket", func() {
+		cancel()
+		gomega.Eventually(done).Should(gomega.Receive(gomega.BeNil()))
+		// A daemon that was killed leaves its socket behind
+		l, err := net.Listen("unix", socketPath)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		l.(*net.UnixListener).SetUnlinkOnClose(false)
+		gomega.Expect(l.Close()).To(gomega.Succeed())
+		gomega.Expect(socketPath).To(gomega.BeAnExistingFile())
+
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		p := fakeProvider{name: "fake", token: "t-restarted", called: &called}
+		go func() { done <- Serve(ctx, socketPath, p) }()
+		gomega.Eventually(func() (string, error) {
+			status, err := RequestToken(context.Background(), socketPath, "fake", execCredentialWithConfig(`{}`))
+			return status.Token, err
+		}).Should(gomega.Equal("t-restarted"))
+	})
+
+	ginkgo.It("should report an unavailable daemon", func() {
+		_, err := RequestToken(context.Background(), socketPath+".missing", "fake", execCredentialWithConfig(`{}`))
+		gomega.Expect(err).To(gomega.MatchError(ErrDaemonUnavailable))
+	})
+})
+
+var _ = ginkgo.Describe("CachedSecretReader", func() {
+	ginkgo.It("should serve Secrets from the informer cache", func(ctx context.Context) {
+		client := fake.NewClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "spoke-1", Namespace: "ns", Labels: map[string]string{"a": "b"}},
+			Data:       map[string][]byte{"token": []byte("t")},
+		})
+		r := NewCachedSecretReader(ctx, client, 0, nil)
+
+		sec, err := r.GetSecret(ctx, "ns", "spoke-1")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(sec.Data["token"]).To(gomega.Equal([]byte("t")))
+
+		items, err := r.ListSecrets(ctx, "ns", labels.SelectorFromSet(labels.Set{"a": "b"}))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(items).To(gomega.HaveLen(1))
+
+		_, err = r.GetSecret(ctx, "ns", "missing")
+		gomega.Expect(apierrors.IsNotFound(err)).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("should reject namespaces outside of the allowed ones", func(ctx context.Context) {
+		r := NewCachedSecretReader(ctx, fake.NewClientset(), 0, []string{"ns"})
+
+		_, err := r.GetSecret(ctx, "other", "spoke-1")
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`namespace "other" is not served`)))
+		_, err = r.ListSecrets(ctx, "other", labels.Everything())
+		gomega.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	ginkgo.It("should report a failed sync until the retry interval has passed", func(ctx context.Context) {
+		client := fake.NewClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "spoke-1", Namespace: "ns"},
+		})
+		var failing atomic.Bool
+		failing.Store(true)
+		client.PrependReactor("list", "secrets", func(k8stesting.Action) (bool, runtime.Object, error) {
+			if failing.Load() {
+				return true, nil, errors.New("list failed")
+			}
+			return false, nil, nil
+		})
+		r := NewCachedSecretReader(ctx, client, 0, nil).(*cachedSecretReader)
+		r.syncTimeout = 100 * time.Millisecond
+		r.retryInterval = time.Second
+
+		_, err := r.GetSecret(ctx, "ns", "spoke-1")
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("failed to sync")))
+
+		// The failure is remembered instead of starting a new informer right away
+		failing.Store(false)
+		_, err = r.GetSecret(ctx, "ns", "spoke-1")
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("failed to sync")))
+
+		gomega.Eventually(func() error {
+			_, err := r.GetSecret(ctx, "ns", "spoke-1")
+			return err
+		}).WithTimeout(5 * time.Second).Should(gomega.Succeed())
+	})
+
+	ginkgo.It("should stop waiting when the request is canceled", func(ctx context.Context) {
+		client := fake.NewClientset()
+		client.PrependReactor("list", "secrets", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("list failed")
+		})
+		r := NewCachedSecretReader(ctx, client, 0, nil)
+
+		reqCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		_, err := r.GetSecret(reqCtx, "ns", "spoke-1")
+		gomega.Expect(err).To(gomega.MatchError(context.DeadlineExceeded))
+	})
+})
@@ -0,0 +1,186 @@
+package credentialplugin
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+// EnvelopePrefix starts every encrypted Secret value. The full format is
+//
+//	nacl-box.v1:<key ID>:<base64 NaCl anonymous sealed box>
+//
+// where the key ID identifies the X25519 public key the value is sealed for.
+const EnvelopePrefix = "nacl-box.v1:"
+
+// envelopeKeyIDLength is the number of hex characters of a key ID.
+const envelopeKeyIDLength = 16
+
+// GenerateEnvelopeKey returns a new X25519 key pair, each base64-encoded as
+// stored in key files.
+func GenerateEnvelopeKey() (publicKey, privateKey string, err error) {
+	pub, priv, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.StdEncoding.EncodeToString(pub[:]), base64.StdEncoding.EncodeToString(priv[:]), nil
+}
+
+// EnvelopeKeyID returns the key ID of a base64-encoded public key.
+func EnvelopeKeyID(publicKey string) (string, error) {
+	pub, err := decodeEnvelopeKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	return envelopeKeyID(pub), nil
+}
+
+// SealEnvelope encrypts plaintext for the base64-encoded public key.
+func SealEnvelope(plaintext []byte, publicKey string) ([]byte, error) {
+	pub, err := decodeEnvelopeKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := box.SealAnonymous(nil, plaintext, pub, rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(EnvelopePrefix + envelopeKeyID(pub) + ":" + base64.StdEncoding.EncodeToString(sealed)), nil
+}
+
+// IsEnvelope reports whether a Secret value is encrypted.
+func IsEnvelope(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(EnvelopePrefix))
+}
+
+// EnvelopeOptions controls how Secret-based plugins decrypt Secret values.
+// The zero value returns plaintext values and rejects encrypted ones.
+type EnvelopeOptions struct {
+	// KeyDir is a directory of base64-encoded X25519 private keys, one per
+	// file, typically a Secret volume mounted only into the consumer. Files
+	// whose name starts with "." and files that are not keys are ignored.
+	// Several keys may be present while values are re-encrypted for a new key.
+	KeyDir string
+	// RequireEncryption, if set, rejects plaintext values.
+	RequireEncryption bool
+}
+
+// AddFlags registers the consumer-controlled options on fs, so that every
+// Secret-based plugin exposes them under the same names.
+func (o *EnvelopeOptions) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.KeyDir, "decryption-key-dir", o.KeyDir,
+		"Directory of private keys used to decrypt "+EnvelopePrefix+" Secret values. "+
+			"If unset, encrypted values are rejected.")
+	fs.BoolVar(&o.RequireEncryption, "require-encryption", o.RequireEncryption,
+		"Reject Secret values that are not encrypted.")
+}
+
+// Open returns data, decrypted if it is an envelope.
+func (o EnvelopeOptions) Open(data []byte) ([]byte, error) {
+	if !IsEnvelope(data) {
+		if o.RequireEncryption {
+			return nil, errors.New("value is not encrypted and encryption is required")
+		}
+		return data, nil
+	}
+
+	keyID, sealedB64, ok := strings.Cut(strings.TrimSpace(string(data[len(EnvelopePrefix):])), ":")
+	if !ok {
+		return nil, errors.New("malformed encrypted value: missing key ID")
+	}
+	sealed, err := base64.StdEncoding.DecodeString(sealedB64)
+	if err != nil {
+		return nil, fmt.Errorf("malformed encrypted value: %w", err)
+	}
+	if o.KeyDir == "" {
+		return nil, fmt.Errorf("value is encrypted for key %s but no decryption key directory is configured", keyID)
+	}
+	pub, priv, err := o.findKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, ok := box.OpenAnonymous(nil, sealed, pub, priv)
+	if !ok {
+		return nil, fmt.Errorf("failed to decrypt value with key %s", keyID)
+	}
+	return plaintext, nil
+}
+
+// findKey returns the key pair with keyID from KeyDir. Keys are read on every
+// call so that rotated key files are picked up by long-running daemons. Files
+// that are not valid keys are skipped, so that unrelated files in KeyDir do not
+// break decryption, and are only reported if no key matches.
+func (o EnvelopeOptions) findKey(keyID string) (pub, priv *[32]byte, err error) {
+	entries, err := os.ReadDir(o.KeyDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read decryption key directory: %w", err)
+	}
+	var invalid []error
+	for _, entry := range entries {
+		// Skip the ..data and timestamped directories of Secret volumes
+		if strings.HasPrefix(entry.Name(), ".") || entry.IsDir() {
+			continue
+		}
+		pub, priv, err := readEnvelopeKey(filepath.Join(o.KeyDir, entry.Name()))
+		if err != nil {
+			invalid = append(invalid, fmt.Errorf("%s: %w", entry.Name(), err))
+			continue
+		}
+		if envelopeKeyID(pub) == keyID {
+			return pub, priv, nil
+		}
+	}
+	if len(invalid) > 0 {
+		return nil, nil, fmt.Errorf("no decryption key with ID %s in %s; skipped invalid keys: %w",
+			keyID, o.KeyDir, errors.Join(invalid...))
+	}
+	return nil, nil, fmt.Errorf("no decryption key with ID %s in %s", keyID, o.KeyDir)
+}
+
+// readEnvelopeKey reads the private key in path and derives its public key.
+func readEnvelopeKey(path string) (pub, priv *[32]byte, err error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	priv, err = decodeEnvelopeKey(string(raw))
+	if err != nil {
+		return nil, nil, err
+	}
+	x25519, err := ecdh.X25519().NewPrivateKey(priv[:])
+	if err != nil {
+		return nil, nil, err
+	}
+	pub = new([32]byte)
+	copy(pub[:], x25519.PublicKey().Bytes())
+	return pub, priv, nil
+}
+
+func decodeEnvelopeKey(s string) (*[32]byte, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("key is not base64: %w", err)
+	}
+	if len(raw) != 32 {
+		return nil, fmt.Errorf("key has %d bytes, expected 32", len(raw))
+	}
+	key := new([32]byte)
+	copy(key[:], raw)
+	return key, nil
+}
+
+func envelopeKeyID(pub *[32]byte) string {
+	sum := sha256.Sum256(pub[:])
+	return hex.EncodeToString(sum[:])[:envelopeKeyIDLength]
+}
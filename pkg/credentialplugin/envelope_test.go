@@ -0,0 +1,125 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentialplugin
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("Envelope", func() {
+	var (
+		keyDir     string
+		publicKey  string
+		privateKey string
+	)
+
+	ginkgo.BeforeEach(func() {
+		keyDir = ginkgo.GinkgoT().TempDir()
+		var err error
+		publicKey, privateKey, err = GenerateEnvelopeKey()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(os.WriteFile(filepath.Join(keyDir, "current"), []byte(privateKey+"\n"), 0o600)).To(gomega.Succeed())
+	})
+
+	ginkgo.It("should round-trip a value sealed for a mounted key", func() {
+		sealed, err := SealEnvelope([]byte("spoke-token"), publicKey)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(IsEnvelope(sealed)).To(gomega.BeTrue())
+		gomega.Expect(string(sealed)).NotTo(gomega.ContainSubstring("spoke-token"))
+
+		keyID, err := EnvelopeKeyID(publicKey)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(string(sealed)).To(gomega.HavePrefix(EnvelopePrefix + keyID + ":"))
+
+		// Secret values written with kubectl often end with a newline
+		plaintext, err := EnvelopeOptions{KeyDir: keyDir}.Open(append(sealed, '\n'))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(string(plaintext)).To(gomega.Equal("spoke-token"))
+	})
+
+	ginkgo.It("should select the key by ID among several keys", func() {
+		nextPublic, nextPrivate, err := GenerateEnvelopeKey()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(os.WriteFile(filepath.Join(keyDir, "next"), []byte(nextPrivate), 0o600)).To(gomega.Succeed())
+		// Entries of Secret volumes starting with "." are skipped
+		gomega.Expect(os.Mkdir(filepath.Join(keyDir, "..data"), 0o700)).To(gomega.Succeed())
+
+		for _, pub := range []string{publicKey, nextPublic} {
+			sealed, err := SealEnvelope([]byte("spoke-token"), pub)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			plaintext, err := EnvelopeOptions{KeyDir: keyDir}.Open(sealed)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(string(plaintext)).To(gomega.Equal("spoke-token"))
+		}
+	})
+
+	ginkgo.It("should report a value sealed for an unknown key", func() {
+		otherPublic, _, err := GenerateEnvelopeKey()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		sealed, err := SealEnvelope([]byte("spoke-token"), otherPublic)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		otherID, err := EnvelopeKeyID(otherPublic)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		_, err = EnvelopeOptions{KeyDir: keyDir}.Open(sealed)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("no decryption key with ID " + otherID)))
+	})
+
+	ginkgo.It("should skip files that are not keys", func() {
+		// Sort the invalid file before the key, so that it is read first
+		gomega.Expect(os.WriteFile(filepath.Join(keyDir, "README"), []byte("not a key"), 0o600)).To(gomega.Succeed())
+		sealed, err := SealEnvelope([]byte("spoke-token"), publicKey)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		plaintext, err := EnvelopeOptions{KeyDir: keyDir}.Open(sealed)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(string(plaintext)).To(gomega.Equal("spoke-token"))
+
+		// Skipped files are reported when no key matches
+		gomega.Expect(os.Remove(filepath.Join(keyDir, "current"))).To(gomega.Succeed())
+		_, err = EnvelopeOptions{KeyDir: keyDir}.Open(sealed)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("skipped invalid keys: README: key is not base64")))
+	})
+
+	ginkgo.It("should reject a tampered value", func() {
+		sealed, err := SealEnvelope([]byte("spoke-token"), publicKey)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		i := strings.LastIndex(string(sealed), ":") + 5
+		if sealed[i] == 'A' {
+			sealed[i] = 'B'
+		} else {
+			sealed[i] = 'A'
+		}
+
+		_, err = EnvelopeOptions{KeyDir: keyDir}.Open(sealed)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("failed to decrypt")))
+	})
+
+	ginkgo.It("should pass plaintext through unless encryption is required", func() {
+		plaintext, err := EnvelopeOptions{}.Open([]byte("spoke-token"))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(string(plaintext)).To(gomega.Equal("spoke-token"))
+
+		_, err = EnvelopeOptions{RequireEncryption: true}.Open([]byte("spoke-token"))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("not encrypted")))
+	})
+})
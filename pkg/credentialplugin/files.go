@@ -0,0 +1,31 @@
+package credentialplugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ReadFileInRoot reads path, resolved relative to root if it is not absolute.
+// Symlinks are resolved first, and the file must be inside root, so that a
+// path coming from a ClusterProfile or a Secret cannot read arbitrary files of
+// the consumer.
+func ReadFileInRoot(root, path string) ([]byte, error) {
+	rootAbs, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid credential file root %q: %w", root, err)
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(rootAbs, path)
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %q: %w", path, err)
+	}
+	rel, err := filepath.Rel(rootAbs, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("file path %q is outside of credential file root %q", path, root)
+	}
+	return os.ReadFile(resolved)
+}
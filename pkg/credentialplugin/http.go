@@ -0,0 +1,17 @@
+package credentialplugin
+
+import (
+	"net/http"
+	"time"
+)
+
+// HTTPTimeout bounds every HTTP request of a plugin, including reading the
+// response, so that an unresponsive endpoint cannot hang the client that
+// executes the plugin.
+const HTTPTimeout = 30 * time.Second
+
+// NewHTTPClient returns an HTTP client with HTTPTimeout that sends requests
+// through transport, or through http.DefaultTransport if transport is nil.
+func NewHTTPClient(transport http.RoundTripper) *http.Client {
+	return &http.Client{Transport: transport, Timeout: HTTPTimeout}
+}
@@ -0,0 +1,63 @@
+package credentialplugin
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultExpirationSkew is subtracted from a credential's expiry so that
+// client-go refreshes it slightly before the server starts rejecting it.
+const DefaultExpirationSkew = 30 * time.Second
+
+// ErrNoExpiry is returned by JWTExpiry when the token is a JWT without an "exp" claim.
+var ErrNoExpiry = errors.New("JWT has no exp claim")
+
+// JWTExpiry returns the "exp" claim of a JWT.
+//
+// The token signature is NOT verified: the result is only a hint for when to
+// refresh the credential and must not be used for any authorization decision.
+func JWTExpiry(token string) (time.Time, error) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return time.Time{}, errors.New("token is not a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to decode JWT payload: %w", err)
+	}
+	var claims struct {
+		Exp *json.Number `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse JWT claims: %w", err)
+	}
+	if claims.Exp == nil {
+		return time.Time{}, ErrNoExpiry
+	}
+	exp, err := claims.Exp.Float64()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid JWT exp claim: %w", err)
+	}
+	return time.Unix(int64(exp), 0).UTC(), nil
+}
+
+// ExpirationTimestamp converts an expiry into an ExecCredentialStatus.ExpirationTimestamp,
+// moving it earlier by skew. If the skewed time has already passed, the
+// unskewed expiry is used instead. A zero expiry yields nil.
+func ExpirationTimestamp(expiry time.Time, skew time.Duration) *metav1.Time {
+	if expiry.IsZero() {
+		return nil
+	}
+	t := expiry.Add(-skew)
+	if t.Before(time.Now()) {
+		t = expiry
+	}
+	mt := metav1.NewTime(t.UTC())
+	return &mt
+}
@@ -0,0 +1,66 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentialplugin
+
+import (
+	"encoding/base64"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+func unsignedJWT(claims string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"none"}`)) + "." + enc.EncodeToString([]byte(claims)) + ".sig"
+}
+
+var _ = ginkgo.Describe("JWTExpiry", func() {
+	ginkgo.It("should return the exp claim", func() {
+		exp, err := JWTExpiry(unsignedJWT(`{"sub":"a","exp":1700000000}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(exp).To(gomega.Equal(time.Unix(1700000000, 0).UTC()))
+	})
+
+	ginkgo.It("should report a JWT without exp", func() {
+		_, err := JWTExpiry(unsignedJWT(`{"sub":"a"}`))
+		gomega.Expect(err).To(gomega.MatchError(ErrNoExpiry))
+	})
+
+	ginkgo.It("should reject opaque tokens", func() {
+		_, err := JWTExpiry("opaque-token")
+		gomega.Expect(err).To(gomega.HaveOccurred())
+	})
+})
+
+var _ = ginkgo.Describe("ExpirationTimestamp", func() {
+	ginkgo.It("should subtract the skew", func() {
+		exp := time.Now().Add(time.Hour).Truncate(time.Second)
+		ts := ExpirationTimestamp(exp, time.Minute)
+		gomega.Expect(ts.Time).To(gomega.BeTemporally("==", exp.Add(-time.Minute)))
+	})
+
+	ginkgo.It("should not skew past the current time", func() {
+		exp := time.Now().Add(10 * time.Second).Truncate(time.Second)
+		ts := ExpirationTimestamp(exp, time.Minute)
+		gomega.Expect(ts.Time).To(gomega.BeTemporally("==", exp))
+	})
+
+	ginkgo.It("should return nil for an unknown expiry", func() {
+		gomega.Expect(ExpirationTimestamp(time.Time{}, time.Minute)).To(gomega.BeNil())
+	})
+})
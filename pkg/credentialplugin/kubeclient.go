@@ -0,0 +1,54 @@
+package credentialplugin
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// KubeClientConfig returns the config of the cluster the plugin reads Secrets
+// from: the in-cluster config if the plugin runs in a Pod, and otherwise the
+// kubeconfig named by $KUBECONFIG.
+func KubeClientConfig() (*rest.Config, error) {
+	cfg, err := rest.InClusterConfig()
+	if err == nil {
+		return cfg, nil
+	}
+	cfg, err = clientcmd.BuildConfigFromFlags("", os.Getenv("KUBECONFIG"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kube client config: %w", err)
+	}
+	return cfg, nil
+}
+
+// NewKubeClient returns a clientset for KubeClientConfig.
+func NewKubeClient() (kubernetes.Interface, error) {
+	cfg, err := KubeClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes clientset: %w", err)
+	}
+	return client, nil
+}
+
+// InferNamespace returns the namespace to read Secrets from, preferring the
+// kubeconfig current-context namespace and falling back to the namespace of
+// the Pod this process runs in.
+func InferNamespace() string {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if path := os.Getenv("KUBECONFIG"); strings.TrimSpace(path) != "" {
+		rules.ExplicitPath = path
+	}
+	cc := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{})
+	if n, _, err := cc.Namespace(); err == nil && strings.TrimSpace(n) != "" {
+		return n
+	}
+	return "default"
+}
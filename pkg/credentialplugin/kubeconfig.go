@@ -0,0 +1,204 @@
+package credentialplugin
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// KubeconfigOptions controls how KubeconfigCredentials extracts user
+// credentials from a kubeconfig. The zero value is the most restrictive.
+type KubeconfigOptions struct {
+	// Context is the kubeconfig context to use. If empty, the context whose
+	// cluster server matches ExecCredential.Spec.Cluster is used, falling back
+	// to the current-context.
+	Context string
+	// AllowedExecCommands lists the commands that a kubeconfig `user.exec` may run.
+	// If empty, kubeconfigs whose user uses exec are rejected.
+	AllowedExecCommands []string
+	// AllowedExecEnv lists the names of the `user.exec.env` entries passed to
+	// the command. Other entries are dropped.
+	AllowedExecEnv []string
+	// CredentialFileRoot, if set, enables `client-certificate`/`client-key` file paths
+	// in kubeconfigs. Relative paths are resolved against this directory and
+	// absolute paths must point inside it.
+	CredentialFileRoot string
+	// IgnoreExtensions, if set, accepts kubeconfigs that carry extensions instead of rejecting them.
+	IgnoreExtensions bool
+	// AllowClusterMismatch, if set, skips checking that the server and CA of the
+	// selected kubeconfig cluster match ExecCredential.Spec.Cluster.
+	AllowClusterMismatch bool
+}
+
+// AddFlags registers the consumer-controlled options on fs, so that every
+// kubeconfig-based plugin exposes them under the same names.
+func (o *KubeconfigOptions) AddFlags(fs *flag.FlagSet) {
+	fs.Var((*StringList)(&o.AllowedExecCommands), "allowed-exec-command",
+		"Command that a kubeconfig user.exec may run (name or absolute path). Repeat to allow several. "+
+			"If unset, exec users are rejected.")
+	fs.Var((*StringList)(&o.AllowedExecEnv), "allowed-exec-env",
+		"Name of a kubeconfig user.exec.env variable passed to the command. Repeat to allow several. "+
+			"If unset, the env of exec users is dropped.")
+	fs.StringVar(&o.CredentialFileRoot, "credential-file-root", o.CredentialFileRoot,
+		"Directory against which kubeconfig client-certificate/client-key file paths are resolved. "+
+			"If unset, file paths are rejected.")
+	fs.BoolVar(&o.IgnoreExtensions, "ignore-kubeconfig-extensions", o.IgnoreExtensions,
+		"Accept kubeconfigs that carry extensions instead of rejecting them.")
+	fs.BoolVar(&o.AllowClusterMismatch, "allow-cluster-mismatch", o.AllowClusterMismatch,
+		"Return credentials even if the kubeconfig cluster server or CA differs from the ClusterProfile.")
+}
+
+// KubeconfigCredentials returns the credentials of the user of the selected
+// context of config. cluster is ExecCredential.Spec.Cluster and must be set;
+// unless AllowClusterMismatch is set, the selected kubeconfig cluster must
+// describe it.
+func KubeconfigCredentials(
+	ctx context.Context,
+	config *clientcmdapi.Config,
+	cluster *clientauthenticationv1.Cluster,
+	opts KubeconfigOptions,
+) (clientauthenticationv1.ExecCredentialStatus, error) {
+	if cluster == nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
+			"missing ExecCredential.Spec.Cluster; set provideClusterInfo in the exec config")
+	}
+
+	// Check for unsupported extensions
+	if len(config.Extensions) > 0 && !opts.IgnoreExtensions {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("kubeconfig extensions are not supported")
+	}
+
+	// Determine context: use provided context, or the context matching the
+	// ClusterProfile server, or fallback to current-context
+	contextName := opts.Context
+	if contextName == "" {
+		var err error
+		contextName, err = selectContext(config, cluster.Server)
+		if err != nil {
+			return clientauthenticationv1.ExecCredentialStatus{}, err
+		}
+	}
+
+	// Get context
+	context, ok := config.Contexts[contextName]
+	if !ok {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("context %q not found in kubeconfig", contextName)
+	}
+
+	// Refuse to return credentials of a cluster other than the ClusterProfile's
+	if !opts.AllowClusterMismatch {
+		if err := checkCluster(context.Cluster, config.Clusters[context.Cluster], cluster); err != nil {
+			return clientauthenticationv1.ExecCredentialStatus{}, err
+		}
+	}
+
+	// Get user
+	user, ok := config.AuthInfos[context.AuthInfo]
+	if !ok {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("user %q not found in kubeconfig", context.AuthInfo)
+	}
+
+	// auth-provider plugins are deprecated in client-go and cannot be run outside of it
+	if user.AuthProvider != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
+			"user %q uses auth-provider %q, which is not supported",
+			context.AuthInfo,
+			user.AuthProvider.Name,
+		)
+	}
+
+	// Delegate to the embedded exec plugin, if any
+	if user.Exec != nil {
+		return execUserCredentials(ctx, context.AuthInfo, user.Exec, config.Clusters[context.Cluster],
+			opts.AllowedExecCommands, opts.AllowedExecEnv)
+	}
+
+	// Build ExecCredentialStatus - support both token and client certificate/key
+	status := clientauthenticationv1.ExecCredentialStatus{}
+
+	// Handle token authentication
+	if user.Token != "" {
+		status.Token = user.Token
+	}
+
+	// Handle client certificate/key authentication
+	hasClientCert := len(user.ClientCertificateData) > 0 || user.ClientCertificate != ""
+	hasClientKey := len(user.ClientKeyData) > 0 || user.ClientKey != ""
+
+	if hasClientCert || hasClientKey {
+		// Both certificate and key must be present
+		if !hasClientCert {
+			return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
+				"client-key-data found but no client-certificate-data in user %q",
+				context.AuthInfo,
+			)
+		}
+		if !hasClientKey {
+			return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
+				"client-certificate-data found but no client-key-data in user %q",
+				context.AuthInfo,
+			)
+		}
+
+		// Handle client-certificate-data
+		if len(user.ClientCertificateData) > 0 {
+			// Already decoded (PEM string)
+			status.ClientCertificateData = string(user.ClientCertificateData)
+		} else if user.ClientCertificate != "" {
+			// File path - only supported under the configured root
+			data, err := readRootedFile(opts.CredentialFileRoot, user.ClientCertificate)
+			if err != nil {
+				return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("client-certificate: %w", err)
+			}
+			status.ClientCertificateData = string(data)
+		}
+
+		// Handle client-key-data
+		if len(user.ClientKeyData) > 0 {
+			// Already decoded (PEM string)
+			status.ClientKeyData = string(user.ClientKeyData)
+		} else if user.ClientKey != "" {
+			// File path - only supported under the configured root
+			data, err := readRootedFile(opts.CredentialFileRoot, user.ClientKey)
+			if err != nil {
+				return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("client-key: %w", err)
+			}
+			status.ClientKeyData = string(data)
+		}
+	}
+
+	// At least one authentication method must be present
+	if status.Token == "" && status.ClientCertificateData == "" {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
+			"no authentication method found in user %q "+
+				"(neither token nor client-certificate-data/client-key-data)",
+			context.AuthInfo,
+		)
+	}
+
+	return status, nil
+}
+
+// readRootedFile reads a kubeconfig file path relative to root. Absolute paths
+// are allowed only if they resolve inside root.
+func readRootedFile(root, path string) ([]byte, error) {
+	if root == "" {
+		return nil, fmt.Errorf("file path %q is not supported; use *-data or set --credential-file-root", path)
+	}
+	return ReadFileInRoot(root, path)
+}
+
+// StringList is a flag.Value collecting repeated string flags.
+type StringList []string
+
+func (l *StringList) String() string { return strings.Join(*l, ",") }
+
+// Set implements flag.Value.
+func (l *StringList) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
@@ -0,0 +1,132 @@
+package credentialplugin
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// selectContext returns the name of the kubeconfig context to use when none is
+// configured: the only context whose cluster server matches the expected
+// server, the current-context if several contexts match, or the current-context
+// if none does.
+func selectContext(config *clientcmdapi.Config, server string) (string, error) {
+	var matches []string
+	for name, c := range config.Contexts {
+		if cluster, ok := config.Clusters[c.Cluster]; ok && sameServer(cluster.Server, server) {
+			matches = append(matches, name)
+		}
+	}
+	switch {
+	case len(matches) == 1:
+		return matches[0], nil
+	case len(matches) > 1 && slices.Contains(matches, config.CurrentContext):
+		return config.CurrentContext, nil
+	case len(matches) > 1:
+		sort.Strings(matches)
+		return "", fmt.Errorf(
+			"several kubeconfig contexts match server %q (%s); set context in ExecCredential.Spec.Cluster.Config",
+			server, strings.Join(matches, ", "),
+		)
+	}
+	if config.CurrentContext == "" {
+		return "", fmt.Errorf("no context specified, no context matches server %q and no current-context in kubeconfig", server)
+	}
+	return config.CurrentContext, nil
+}
+
+// checkCluster verifies that the kubeconfig cluster is the cluster described
+// by the ClusterProfile, so that credentials of one cluster are never handed
+// to a client talking to another one.
+func checkCluster(name string, cluster *clientcmdapi.Cluster, expected *clientauthenticationv1.Cluster) error {
+	if cluster == nil {
+		return fmt.Errorf("cluster %q not found in kubeconfig", name)
+	}
+	if !sameServer(cluster.Server, expected.Server) {
+		return fmt.Errorf(
+			"kubeconfig cluster %q server %q does not match ClusterProfile server %q",
+			name, cluster.Server, expected.Server,
+		)
+	}
+	same, err := sameCABundle(cluster.CertificateAuthorityData, expected.CertificateAuthorityData)
+	if err != nil {
+		return fmt.Errorf("kubeconfig cluster %q: %w", name, err)
+	}
+	if !same {
+		return fmt.Errorf("kubeconfig cluster %q certificate-authority-data does not match ClusterProfile", name)
+	}
+	return nil
+}
+
+// sameServer reports whether two server URLs point to the same endpoint,
+// ignoring case of scheme and host, default ports and trailing slashes.
+func sameServer(a, b string) bool {
+	na, errA := normalizeServer(a)
+	nb, errB := normalizeServer(b)
+	if errA != nil || errB != nil {
+		return strings.TrimSpace(a) == strings.TrimSpace(b)
+	}
+	return na == nb
+}
+
+func normalizeServer(server string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(server))
+	if err != nil {
+		return "", err
+	}
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	if (scheme == "https" && port == "443") || (scheme == "http" && port == "80") {
+		port = ""
+	}
+	if port != "" {
+		host = host + ":" + port
+	}
+	return scheme + "://" + host + strings.TrimRight(u.Path, "/"), nil
+}
+
+// sameCABundle reports whether two PEM bundles contain the same set of certificates.
+func sameCABundle(a, b []byte) (bool, error) {
+	ca, err := bundleCertificates(a)
+	if err != nil {
+		return false, err
+	}
+	cb, err := bundleCertificates(b)
+	if err != nil {
+		return false, err
+	}
+	return slices.EqualFunc(ca, cb, bytes.Equal), nil
+}
+
+// bundleCertificates returns the sorted DER encoding of every certificate in a PEM bundle.
+func bundleCertificates(data []byte) ([][]byte, error) {
+	var certs [][]byte
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return nil, fmt.Errorf("invalid certificate in CA bundle: %w", err)
+		}
+		certs = append(certs, block.Bytes)
+	}
+	if len(bytes.TrimSpace(data)) > 0 && len(certs) == 0 {
+		return nil, fmt.Errorf("CA bundle is not PEM encoded")
+	}
+	slices.SortFunc(certs, bytes.Compare)
+	return slices.CompactFunc(certs, bytes.Equal), nil
+}
@@ -0,0 +1,146 @@
+package credentialplugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+	clientauthenticationv1beta1 "k8s.io/client-go/pkg/apis/clientauthentication/v1beta1"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// clusterExecExtensionKey is the kubeconfig cluster extension passed to exec
+// plugins as ExecCredential.Spec.Cluster.Config.
+const clusterExecExtensionKey = "client.authentication.k8s.io/exec"
+
+// execTimeout bounds how long a kubeconfig exec user may run.
+const execTimeout = time.Minute
+
+// execUserCredentials runs the exec user of a kubeconfig and returns the
+// credentials it prints. The command must be listed in allowed. Only the env
+// entries of the exec user listed in allowedEnv are passed to the command: the
+// kubeconfig comes from a Secret, and variables such as LD_PRELOAD, PATH or
+// NODE_OPTIONS would let its author load code into, or substitute, the
+// allowed command.
+func execUserCredentials(
+	ctx context.Context,
+	userName string,
+	execConfig *clientcmdapi.ExecConfig,
+	cluster *clientcmdapi.Cluster,
+	allowed []string,
+	allowedEnv []string,
+) (clientauthenticationv1.ExecCredentialStatus, error) {
+	if len(allowed) == 0 {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
+			"user %q uses exec, which is not enabled; see --allowed-exec-command", userName,
+		)
+	}
+	command, err := allowedCommand(execConfig.Command, allowed)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("user %q: %w", userName, err)
+	}
+	if execConfig.InteractiveMode == clientcmdapi.AlwaysExecInteractiveMode {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
+			"user %q: exec with interactiveMode %q is not supported", userName, execConfig.InteractiveMode,
+		)
+	}
+	switch execConfig.APIVersion {
+	case clientauthenticationv1.SchemeGroupVersion.String(), clientauthenticationv1beta1.SchemeGroupVersion.String():
+	default:
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
+			"user %q: unsupported exec apiVersion %q", userName, execConfig.APIVersion,
+		)
+	}
+
+	// Build KUBERNETES_EXEC_INFO. v1 and v1beta1 share the same JSON shape.
+	info := clientauthenticationv1.ExecCredential{
+		TypeMeta: metav1.TypeMeta{APIVersion: execConfig.APIVersion, Kind: "ExecCredential"},
+	}
+	if execConfig.ProvideClusterInfo && cluster != nil {
+		info.Spec.Cluster = execClusterInfo(cluster)
+	}
+	infoJSON, err := json.Marshal(info)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("failed to marshal exec info: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, execTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, command, execConfig.Args...)
+	cmd.Env = os.Environ()
+	for _, env := range execConfig.Env {
+		if slices.Contains(allowedEnv, env.Name) {
+			cmd.Env = append(cmd.Env, env.Name+"="+env.Value)
+		}
+	}
+	cmd.Env = append(cmd.Env, "KUBERNETES_EXEC_INFO="+string(infoJSON))
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
+			"user %q: exec %q failed: %w", userName, execConfig.Command, err,
+		)
+	}
+
+	var out clientauthenticationv1.ExecCredential
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
+			"user %q: failed to parse exec output: %w", userName, err,
+		)
+	}
+	if out.APIVersion != execConfig.APIVersion {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
+			"user %q: exec returned apiVersion %q, expected %q", userName, out.APIVersion, execConfig.APIVersion,
+		)
+	}
+	if out.Status == nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
+			"user %q: exec returned no status", userName,
+		)
+	}
+	return *out.Status, nil
+}
+
+// allowedCommand returns the command to run if it is listed in allowed,
+// either verbatim or after resolving both sides through PATH.
+func allowedCommand(command string, allowed []string) (string, error) {
+	if slices.Contains(allowed, command) {
+		return command, nil
+	}
+	resolved, err := exec.LookPath(command)
+	if err != nil {
+		return "", fmt.Errorf("exec command %q is not allowed", command)
+	}
+	for _, a := range allowed {
+		if r, err := exec.LookPath(a); err == nil && r == resolved {
+			return resolved, nil
+		}
+	}
+	return "", fmt.Errorf("exec command %q is not allowed", command)
+}
+
+// execClusterInfo converts a kubeconfig cluster into the cluster passed to exec plugins.
+func execClusterInfo(cluster *clientcmdapi.Cluster) *clientauthenticationv1.Cluster {
+	out := &clientauthenticationv1.Cluster{
+		Server:                   cluster.Server,
+		TLSServerName:            cluster.TLSServerName,
+		InsecureSkipTLSVerify:    cluster.InsecureSkipTLSVerify,
+		CertificateAuthorityData: cluster.CertificateAuthorityData,
+		ProxyURL:                 cluster.ProxyURL,
+		DisableCompression:       cluster.DisableCompression,
+	}
+	if ext, ok := cluster.Extensions[clusterExecExtensionKey].(*runtime.Unknown); ok {
+		out.Config = runtime.RawExtension{Raw: ext.Raw}
+	}
+	return out
+}
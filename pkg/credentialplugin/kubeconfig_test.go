@@ -0,0 +1,216 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentialplugin
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	certutil "k8s.io/client-go/util/cert"
+)
+
+const twoClusterKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: a
+  cluster:
+    server: https://a.example.com:443/
+    certificate-authority-data: %CA_A%
+- name: b
+  cluster:
+    server: https://b.example.com
+    certificate-authority-data: %CA_B%
+contexts:
+- name: a
+  context:
+    cluster: a
+    user: a
+- name: b
+  context:
+    cluster: b
+    user: b
+current-context: a
+users:
+- name: a
+  user:
+    token: token-a
+- name: b
+  user:
+    token: token-b
+`
+
+var _ = ginkgo.Describe("KubeconfigCredentials", func() {
+	var caA, caB []byte
+	var config *clientcmdapi.Config
+
+	ginkgo.BeforeEach(func() {
+		var err error
+		caA, _, err = certutil.GenerateSelfSignedCertKey("a.example.com", nil, nil)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		caB, _, err = certutil.GenerateSelfSignedCertKey("b.example.com", nil, nil)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		config, err = clientcmd.Load([]byte(strings.NewReplacer(
+			"%CA_A%", base64.StdEncoding.EncodeToString(caA),
+			"%CA_B%", base64.StdEncoding.EncodeToString(caB),
+		).Replace(twoClusterKubeconfig)))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	ginkgo.It("should select the context whose server matches the ClusterProfile", func() {
+		cluster := &clientauthenticationv1.Cluster{Server: "https://B.example.com/", CertificateAuthorityData: caB}
+		status, err := KubeconfigCredentials(context.Background(), config, cluster, KubeconfigOptions{})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(status.Token).To(gomega.Equal("token-b"))
+	})
+
+	ginkgo.It("should refuse a context whose server differs from the ClusterProfile", func() {
+		cluster := &clientauthenticationv1.Cluster{Server: "https://b.example.com", CertificateAuthorityData: caB}
+		_, err := KubeconfigCredentials(context.Background(), config, cluster, KubeconfigOptions{Context: "a"})
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("does not match ClusterProfile server")))
+	})
+
+	ginkgo.It("should refuse a context whose CA differs from the ClusterProfile", func() {
+		cluster := &clientauthenticationv1.Cluster{Server: "https://a.example.com", CertificateAuthorityData: caB}
+		_, err := KubeconfigCredentials(context.Background(), config, cluster, KubeconfigOptions{})
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("certificate-authority-data does not match")))
+	})
+
+	ginkgo.It("should return credentials on mismatch when explicitly allowed", func() {
+		cluster := &clientauthenticationv1.Cluster{Server: "https://c.example.com"}
+		status, err := KubeconfigCredentials(context.Background(), config, cluster,
+			KubeconfigOptions{AllowClusterMismatch: true})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(status.Token).To(gomega.Equal("token-a"))
+	})
+
+	ginkgo.It("should reject a missing cluster", func() {
+		_, err := KubeconfigCredentials(context.Background(), config, nil,
+			KubeconfigOptions{Context: "a", AllowClusterMismatch: true})
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("missing ExecCredential.Spec.Cluster")))
+	})
+})
+
+var _ = ginkgo.Describe("selectContext", func() {
+	config := func(current string, servers map[string]string) *clientcmdapi.Config {
+		c := clientcmdapi.NewConfig()
+		c.CurrentContext = current
+		for name, server := range servers {
+			c.Clusters[name] = &clientcmdapi.Cluster{Server: server}
+			c.Contexts[name] = &clientcmdapi.Context{Cluster: name}
+		}
+		return c
+	}
+
+	ginkgo.It("should prefer the only context matching the server", func() {
+		name, err := selectContext(config("a", map[string]string{
+			"a": "https://a.example.com", "b": "https://b.example.com:443",
+		}), "https://B.example.com/")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(name).To(gomega.Equal("b"))
+	})
+
+	ginkgo.It("should use the current-context among several matches", func() {
+		name, err := selectContext(config("b", map[string]string{
+			"a": "https://spoke.example.com", "b": "https://spoke.example.com",
+		}), "https://spoke.example.com")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(name).To(gomega.Equal("b"))
+	})
+
+	ginkgo.It("should reject several matches without the current-context", func() {
+		_, err := selectContext(config("c", map[string]string{
+			"a": "https://spoke.example.com", "b": "https://spoke.example.com", "c": "https://other.example.com",
+		}), "https://spoke.example.com")
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("several kubeconfig contexts match server")))
+	})
+
+	ginkgo.It("should fall back to the current-context if none matches", func() {
+		name, err := selectContext(config("a", map[string]string{"a": "https://a.example.com"}), "https://c.example.com")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(name).To(gomega.Equal("a"))
+
+		_, err = selectContext(config("", map[string]string{"a": "https://a.example.com"}), "https://c.example.com")
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("no current-context")))
+	})
+})
+
+var _ = ginkgo.Describe("checkCluster", func() {
+	ginkgo.It("should reject a context without a cluster", func() {
+		err := checkCluster("gone", nil, &clientauthenticationv1.Cluster{Server: "https://a.example.com"})
+		gomega.Expect(err).To(gomega.MatchError(`cluster "gone" not found in kubeconfig`))
+	})
+
+	ginkgo.It("should compare CA bundles as sets of certificates", func() {
+		caA, _, err := certutil.GenerateSelfSignedCertKey("a.example.com", nil, nil)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		caB, _, err := certutil.GenerateSelfSignedCertKey("b.example.com", nil, nil)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		cluster := &clientcmdapi.Cluster{Server: "https://a.example.com", CertificateAuthorityData: append(caA, caB...)}
+		expected := &clientauthenticationv1.Cluster{Server: "https://a.example.com", CertificateAuthorityData: append(caB, caA...)}
+		gomega.Expect(checkCluster("a", cluster, expected)).To(gomega.Succeed())
+
+		expected.CertificateAuthorityData = []byte("not PEM")
+		gomega.Expect(checkCluster("a", cluster, expected)).To(gomega.MatchError(gomega.ContainSubstring("not PEM encoded")))
+	})
+})
+
+var _ = ginkgo.Describe("execUserCredentials", func() {
+	var script string
+
+	// run execs script with env and returns the token it printed, which is
+	// the value of the variable named by its argument.
+	run := func(name string, env []clientcmdapi.ExecEnvVar, allowedEnv []string) string {
+		status, err := execUserCredentials(context.Background(), "admin", &clientcmdapi.ExecConfig{
+			Command:    script,
+			Args:       []string{name},
+			APIVersion: clientauthenticationv1.SchemeGroupVersion.String(),
+			Env:        env,
+		}, nil, []string{script}, allowedEnv)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		return status.Token
+	}
+
+	ginkgo.BeforeEach(func() {
+		script = filepath.Join(ginkgo.GinkgoT().TempDir(), "print-env")
+		gomega.Expect(os.WriteFile(script, []byte(`#!/bin/sh
+eval "value=\${$1}"
+echo '{"apiVersion":"client.authentication.k8s.io/v1","kind":"ExecCredential","status":{"token":"'"$value"'"}}'
+`), 0o755)).To(gomega.Succeed())
+	})
+
+	ginkgo.It("should drop env that is not allowed", func() {
+		for _, name := range []string{"NODE_OPTIONS", "BASH_ENV", "LD_PRELOAD", "AWS_PROFILE"} {
+			env := []clientcmdapi.ExecEnvVar{{Name: name, Value: "/tmp/x"}}
+			gomega.Expect(run(name, env, nil)).To(gomega.BeEmpty(), name)
+			gomega.Expect(run(name, env, []string{"OTHER"})).To(gomega.BeEmpty(), name)
+		}
+	})
+
+	ginkgo.It("should pass allowed env to the command", func() {
+		env := []clientcmdapi.ExecEnvVar{{Name: "AWS_PROFILE", Value: "spoke"}}
+		gomega.Expect(run("AWS_PROFILE", env, []string{"AWS_PROFILE"})).To(gomega.Equal("spoke"))
+	})
+})
@@ -0,0 +1,241 @@
+package credentialplugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// In the rotation layout, a Secret holds several versions of the credential
+// stored under a data key, so that a new credential can be written while
+// clients still use the previous one:
+//
+//	<key>.<version>            the credential
+//	<key>.<version>.notBefore  optional RFC 3339 time the version becomes valid
+//	<key>.<version>.notAfter   optional RFC 3339 time the version expires
+//
+// Versions are positive integers. Readers use the highest version that is
+// valid, and ignore <key> itself once a version exists.
+const (
+	notBeforeSuffix = ".notBefore"
+	notAfterSuffix  = ".notAfter"
+)
+
+// CredentialVersion is one version of a credential in the rotation layout.
+type CredentialVersion struct {
+	Version int
+	Value   []byte
+	// NotBefore is when the version becomes valid. Zero means it already is.
+	NotBefore time.Time
+	// NotAfter is when the version expires. Zero means it does not expire.
+	NotAfter time.Time
+}
+
+// ValidAt reports whether the version may be used at t.
+func (v CredentialVersion) ValidAt(t time.Time) bool {
+	return !t.Before(v.NotBefore) && (v.NotAfter.IsZero() || t.Before(v.NotAfter))
+}
+
+// CredentialVersions returns the versions of key in data, lowest version
+// first. It returns no versions if data does not use the rotation layout.
+func CredentialVersions(data map[string][]byte, key string) ([]CredentialVersion, error) {
+	var versions []CredentialVersion
+	for k, value := range data {
+		rest, ok := strings.CutPrefix(k, key+".")
+		if !ok {
+			continue
+		}
+		version, err := strconv.Atoi(rest)
+		if err != nil || version <= 0 || strconv.Itoa(version) != rest {
+			// Metadata and unrelated keys
+			continue
+		}
+		v := CredentialVersion{Version: version, Value: value}
+		if v.NotBefore, err = rotationTime(data, k+notBeforeSuffix); err != nil {
+			return nil, err
+		}
+		if v.NotAfter, err = rotationTime(data, k+notAfterSuffix); err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+	return versions, nil
+}
+
+// CurrentCredential returns the highest version of key that is valid at now.
+// ok is false if data does not use the rotation layout for key.
+func CurrentCredential(data map[string][]byte, key string, now time.Time) (v CredentialVersion, ok bool, err error) {
+	versions, err := CredentialVersions(data, key)
+	if err != nil || len(versions) == 0 {
+		return CredentialVersion{}, false, err
+	}
+	for i := len(versions) - 1; i >= 0; i-- {
+		if versions[i].ValidAt(now) && len(versions[i].Value) > 0 {
+			return versions[i], true, nil
+		}
+	}
+	return CredentialVersion{}, true, fmt.Errorf("none of the %d versions of %q is valid at %s",
+		len(versions), key, now.UTC().Format(time.RFC3339))
+}
+
+func rotationTime(data map[string][]byte, key string) (time.Time, error) {
+	raw := strings.TrimSpace(string(data[key]))
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %q value: %w", key, err)
+	}
+	return t, nil
+}
+
+// CredentialRotation describes a new version of a credential.
+type CredentialRotation struct {
+	// Key is the Secret data key of the credential, e.g. "token".
+	Key string
+	// Value is the new credential.
+	Value []byte
+	// NotBefore is when readers switch to the new version. Zero means now.
+	NotBefore time.Time
+	// NotAfter is when the new version expires. It is required, because it is
+	// the expiry reported to clients and thus when they ask for a newer version.
+	NotAfter time.Time
+}
+
+// AddCredentialVersion adds r to data as the version following the highest
+// existing one, and returns that version.
+//
+// It refuses rotations that would leave clients without a valid credential:
+// the new version must become valid before the versions that are valid at now
+// have all expired.
+func AddCredentialVersion(data map[string][]byte, r CredentialRotation, now time.Time) (int, error) {
+	if r.Key == "" || len(r.Value) == 0 {
+		return 0, errors.New("rotation requires a key and a value")
+	}
+	if r.NotAfter.IsZero() {
+		return 0, errors.New("rotation requires notAfter")
+	}
+	notBefore := r.NotBefore
+	if notBefore.IsZero() {
+		notBefore = now
+	}
+	if !r.NotAfter.After(notBefore) || !r.NotAfter.After(now) {
+		return 0, fmt.Errorf("notAfter %s must be after notBefore and now", r.NotAfter.UTC().Format(time.RFC3339))
+	}
+
+	versions, err := CredentialVersions(data, r.Key)
+	if err != nil {
+		return 0, err
+	}
+	next := 1
+	var validUntil time.Time
+	openEnded := false
+	for _, v := range versions {
+		next = v.Version + 1
+		if !v.ValidAt(now) {
+			continue
+		}
+		if v.NotAfter.IsZero() {
+			openEnded = true
+		} else if v.NotAfter.After(validUntil) {
+			validUntil = v.NotAfter
+		}
+	}
+	if notBefore.After(now) && !openEnded && notBefore.After(validUntil) {
+		return 0, fmt.Errorf("notBefore %s is after all current versions of %q expire",
+			notBefore.UTC().Format(time.RFC3339), r.Key)
+	}
+
+	k := r.Key + "." + strconv.Itoa(next)
+	data[k] = r.Value
+	if !r.NotBefore.IsZero() {
+		data[k+notBeforeSuffix] = []byte(r.NotBefore.UTC().Format(time.RFC3339))
+	}
+	data[k+notAfterSuffix] = []byte(r.NotAfter.UTC().Format(time.RFC3339))
+	return next, nil
+}
+
+// PruneCredentialVersions removes the versions of key that expired before
+// now, and returns them.
+func PruneCredentialVersions(data map[string][]byte, key string, now time.Time) ([]int, error) {
+	versions, err := CredentialVersions(data, key)
+	if err != nil {
+		return nil, err
+	}
+	var pruned []int
+	for _, v := range versions {
+		if v.NotAfter.IsZero() || now.Before(v.NotAfter) {
+			continue
+		}
+		k := key + "." + strconv.Itoa(v.Version)
+		delete(data, k)
+		delete(data, k+notBeforeSuffix)
+		delete(data, k+notAfterSuffix)
+		pruned = append(pruned, v.Version)
+	}
+	return pruned, nil
+}
+
+// RotateSecretCredential adds r as a new version to the Secret
+// <namespace>/<name> and prunes expired versions of r.Key. The update is
+// retried on conflicts, so concurrent writers never lose a version.
+func RotateSecretCredential(
+	ctx context.Context,
+	client kubernetes.Interface,
+	namespace, name string,
+	r CredentialRotation,
+) (int, error) {
+	var version int
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		sec, err := client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		sec = sec.DeepCopy()
+		if sec.Data == nil {
+			sec.Data = map[string][]byte{}
+		}
+		now := time.Now()
+		if version, err = AddCredentialVersion(sec.Data, r, now); err != nil {
+			return err
+		}
+		if _, err := PruneCredentialVersions(sec.Data, r.Key, now); err != nil {
+			return err
+		}
+		_, err = client.CoreV1().Secrets(namespace).Update(ctx, sec, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to rotate %q of secret %s/%s: %w", r.Key, namespace, name, err)
+	}
+	return version, nil
+}
+
+// SecretCredential returns the credential stored under key in sec: the
+// current version if sec uses the rotation layout, otherwise the value of key.
+// notAfter is the expiry of the returned version, zero if unknown.
+func SecretCredential(sec *corev1.Secret, key string, now time.Time) (value []byte, notAfter time.Time, err error) {
+	v, ok, err := CurrentCredential(sec.Data, key, now)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if ok {
+		return v.Value, v.NotAfter, nil
+	}
+	data := sec.Data[key]
+	if len(data) == 0 {
+		return nil, time.Time{}, fmt.Errorf("missing %q key", key)
+	}
+	return data, time.Time{}, nil
+}
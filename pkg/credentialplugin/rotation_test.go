@@ -0,0 +1,163 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentialplugin
+
+import (
+	"context"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func rfc3339(t time.Time) []byte { return []byte(t.UTC().Format(time.RFC3339)) }
+
+var _ = ginkgo.Describe("Rotation layout", func() {
+	now := time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC)
+
+	ginkgo.Describe("CurrentCredential", func() {
+		ginkgo.It("should return the highest valid version and its notAfter", func() {
+			data := map[string][]byte{
+				"token":             []byte("legacy"),
+				"token.1":           []byte("old"),
+				"token.1.notAfter":  rfc3339(now.Add(time.Hour)),
+				"token.2":           []byte("current"),
+				"token.2.notBefore": rfc3339(now.Add(-time.Minute)),
+				"token.2.notAfter":  rfc3339(now.Add(24 * time.Hour)),
+				"token.3":           []byte("next"),
+				"token.3.notBefore": rfc3339(now.Add(12 * time.Hour)),
+				"token.3.notAfter":  rfc3339(now.Add(48 * time.Hour)),
+				"token.old":         []byte("unrelated"),
+			}
+			v, ok, err := CurrentCredential(data, "token", now)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ok).To(gomega.BeTrue())
+			gomega.Expect(v.Version).To(gomega.Equal(2))
+			gomega.Expect(string(v.Value)).To(gomega.Equal("current"))
+			gomega.Expect(v.NotAfter).To(gomega.Equal(now.Add(24 * time.Hour)))
+
+			v, _, err = CurrentCredential(data, "token", now.Add(13*time.Hour))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(string(v.Value)).To(gomega.Equal("next"))
+		})
+
+		ginkgo.It("should report Secrets without versions", func() {
+			_, ok, err := CurrentCredential(map[string][]byte{"token": []byte("plain")}, "token", now)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ok).To(gomega.BeFalse())
+		})
+
+		ginkgo.It("should fail if no version is valid", func() {
+			_, ok, err := CurrentCredential(map[string][]byte{
+				"token.1":          []byte("expired"),
+				"token.1.notAfter": rfc3339(now.Add(-time.Second)),
+			}, "token", now)
+			gomega.Expect(ok).To(gomega.BeTrue())
+			gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`none of the 1 versions of "token" is valid`)))
+		})
+
+		ginkgo.It("should reject invalid timestamps", func() {
+			_, _, err := CurrentCredential(map[string][]byte{
+				"token.1":          []byte("a"),
+				"token.1.notAfter": []byte("tomorrow"),
+			}, "token", now)
+			gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`invalid "token.1.notAfter" value`)))
+		})
+	})
+
+	ginkgo.Describe("AddCredentialVersion", func() {
+		ginkgo.It("should add the next version with its metadata", func() {
+			data := map[string][]byte{
+				"token.4":          []byte("old"),
+				"token.4.notAfter": rfc3339(now.Add(time.Hour)),
+			}
+			version, err := AddCredentialVersion(data, CredentialRotation{
+				Key:       "token",
+				Value:     []byte("new"),
+				NotBefore: now.Add(30 * time.Minute),
+				NotAfter:  now.Add(25 * time.Hour),
+			}, now)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(version).To(gomega.Equal(5))
+			gomega.Expect(data).To(gomega.HaveKeyWithValue("token.5", []byte("new")))
+			gomega.Expect(data).To(gomega.HaveKeyWithValue("token.5.notBefore", rfc3339(now.Add(30*time.Minute))))
+			gomega.Expect(data).To(gomega.HaveKeyWithValue("token.5.notAfter", rfc3339(now.Add(25*time.Hour))))
+		})
+
+		ginkgo.It("should refuse a rotation that leaves a gap", func() {
+			data := map[string][]byte{
+				"token.1":          []byte("old"),
+				"token.1.notAfter": rfc3339(now.Add(time.Hour)),
+			}
+			_, err := AddCredentialVersion(data, CredentialRotation{
+				Key:       "token",
+				Value:     []byte("new"),
+				NotBefore: now.Add(2 * time.Hour),
+				NotAfter:  now.Add(25 * time.Hour),
+			}, now)
+			gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("after all current versions")))
+			gomega.Expect(data).NotTo(gomega.HaveKey("token.2"))
+		})
+
+		ginkgo.It("should require notAfter", func() {
+			_, err := AddCredentialVersion(map[string][]byte{}, CredentialRotation{Key: "token", Value: []byte("new")}, now)
+			gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("requires notAfter")))
+		})
+	})
+
+	ginkgo.It("should prune expired versions", func() {
+		data := map[string][]byte{
+			"token.1":           []byte("expired"),
+			"token.1.notBefore": rfc3339(now.Add(-48 * time.Hour)),
+			"token.1.notAfter":  rfc3339(now.Add(-time.Hour)),
+			"token.2":           []byte("current"),
+			"token.2.notAfter":  rfc3339(now.Add(time.Hour)),
+		}
+		pruned, err := PruneCredentialVersions(data, "token", now)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(pruned).To(gomega.Equal([]int{1}))
+		gomega.Expect(data).To(gomega.HaveLen(2))
+		gomega.Expect(data).To(gomega.HaveKey("token.2"))
+	})
+
+	ginkgo.It("should rotate a Secret", func() {
+		client := fake.NewClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "spoke-1", Namespace: "default"},
+			Data: map[string][]byte{
+				"token.1":          []byte("old"),
+				"token.1.notAfter": rfc3339(time.Now().Add(time.Hour)),
+			},
+		})
+		version, err := RotateSecretCredential(context.Background(), client, "default", "spoke-1", CredentialRotation{
+			Key:      "token",
+			Value:    []byte("new"),
+			NotAfter: time.Now().Add(24 * time.Hour),
+		})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(version).To(gomega.Equal(2))
+
+		sec, err := client.CoreV1().Secrets("default").Get(context.Background(), "spoke-1", metav1.GetOptions{})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		value, notAfter, err := SecretCredential(sec, "token", time.Now())
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(string(value)).To(gomega.Equal("new"))
+		gomega.Expect(notAfter).To(gomega.BeTemporally("~", time.Now().Add(24*time.Hour), time.Minute))
+	})
+})
@@ -0,0 +1,254 @@
+package credentialplugin
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// SecretReader reads Secrets on behalf of Secret-based providers, so that the
+// same provider can read through the API server or through an informer cache.
+type SecretReader interface {
+	// GetSecret returns the Secret <namespace>/<name>.
+	GetSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error)
+	// ListSecrets returns the Secrets in namespace that match selector.
+	ListSecrets(ctx context.Context, namespace string, selector labels.Selector) ([]corev1.Secret, error)
+}
+
+// NewClientSecretReader returns a SecretReader that performs a live GET or LIST
+// against the API server on every call.
+func NewClientSecretReader(client kubernetes.Interface) SecretReader {
+	return clientSecretReader{client: client}
+}
+
+// SecretReaderOrClient returns secrets if it is set, and otherwise a
+// SecretReader that reads through client. Secret-based providers use it to
+// default their SecretReader.
+func SecretReaderOrClient(secrets SecretReader, client kubernetes.Interface) SecretReader {
+	if secrets != nil {
+		return secrets
+	}
+	return NewClientSecretReader(client)
+}
+
+// NamespaceOptions restricts the namespaces Secret-based plugins read Secrets
+// from. The namespace is named by the ClusterProfile, so without a restriction
+// its author could make the plugin read any Secret its RBAC allows.
+type NamespaceOptions struct {
+	// AllowedNamespaces lists the namespaces Secrets may be read from. If
+	// empty, only the plugin's own namespace may be read.
+	AllowedNamespaces []string
+}
+
+// AddFlags registers the consumer-controlled options on fs, so that every
+// Secret-based plugin exposes them under the same names.
+func (o *NamespaceOptions) AddFlags(fs *flag.FlagSet) {
+	fs.Var((*StringList)(&o.AllowedNamespaces), "allowed-namespace",
+		"Namespace that Secrets may be read from. Repeat to allow several. "+
+			"If unset, only the inferred namespace may be read.")
+}
+
+// Namespaces returns the namespaces Secrets may be read from, given the
+// plugin's own namespace.
+func (o NamespaceOptions) Namespaces(own string) []string {
+	if len(o.AllowedNamespaces) == 0 {
+		return []string{own}
+	}
+	return o.AllowedNamespaces
+}
+
+// Check returns an error unless Secrets may be read from namespace.
+func (o NamespaceOptions) Check(namespace, own string) error {
+	if !slices.Contains(o.Namespaces(own), namespace) {
+		return fmt.Errorf("namespace %q is not allowed; see --allowed-namespace", namespace)
+	}
+	return nil
+}
+
+type clientSecretReader struct {
+	client kubernetes.Interface
+}
+
+func (r clientSecretReader) GetSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	return r.client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (r clientSecretReader) ListSecrets(
+	ctx context.Context,
+	namespace string,
+	selector labels.Selector,
+) ([]corev1.Secret, error) {
+	list, err := r.client.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// NewCachedSecretReader returns a SecretReader backed by Secret informers.
+// An informer is started for a namespace the first time it is read, and
+// kept running until ctx is done, so later reads are served from memory.
+// If namespaces is not empty, reads of other namespaces are rejected, which
+// bounds the number of informers.
+// The caller needs list and watch permissions on Secrets in those namespaces.
+func NewCachedSecretReader(
+	ctx context.Context,
+	client kubernetes.Interface,
+	resync time.Duration,
+	namespaces []string,
+) SecretReader {
+	r := &cachedSecretReader{
+		ctx:           ctx,
+		client:        client,
+		resync:        resync,
+		syncTimeout:   secretCacheSyncTimeout,
+		retryInterval: secretCacheRetryInterval,
+		caches:        map[string]*secretCache{},
+	}
+	if len(namespaces) > 0 {
+		r.namespaces = sets.New(namespaces...)
+	}
+	return r
+}
+
+const (
+	// secretCacheSyncTimeout bounds the initial sync of a namespace informer.
+	secretCacheSyncTimeout = 30 * time.Second
+	// secretCacheRetryInterval is how long a failed sync is reported to readers
+	// of the namespace before a new informer is started.
+	secretCacheRetryInterval = 10 * time.Second
+)
+
+type cachedSecretReader struct {
+	ctx           context.Context
+	client        kubernetes.Interface
+	resync        time.Duration
+	syncTimeout   time.Duration
+	retryInterval time.Duration
+	// namespaces, if not nil, are the only namespaces that may be read.
+	namespaces sets.Set[string]
+
+	mu     sync.Mutex
+	caches map[string]*secretCache
+}
+
+// secretCache is the informer cache of a single namespace.
+type secretCache struct {
+	// done is closed once the initial sync has finished; the fields below are
+	// only read after that.
+	done     chan struct{}
+	lister   corev1listers.SecretNamespaceLister
+	err      error
+	failedAt time.Time
+}
+
+func (r *cachedSecretReader) GetSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	lister, err := r.lister(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	sec, err := lister.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return sec.DeepCopy(), nil
+}
+
+func (r *cachedSecretReader) ListSecrets(
+	ctx context.Context,
+	namespace string,
+	selector labels.Selector,
+) ([]corev1.Secret, error) {
+	lister, err := r.lister(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := lister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]corev1.Secret, 0, len(secrets))
+	for _, sec := range secrets {
+		out = append(out, *sec.DeepCopy())
+	}
+	return out, nil
+}
+
+// lister returns the lister for namespace, starting its informer on first use
+// and waiting for its initial sync. Concurrent readers of a namespace share a
+// single sync, and a failed sync is reported for retryInterval before it is
+// retried.
+func (r *cachedSecretReader) lister(ctx context.Context, namespace string) (corev1listers.SecretNamespaceLister, error) {
+	if r.namespaces != nil && !r.namespaces.Has(namespace) {
+		return nil, fmt.Errorf("namespace %q is not served by the Secret cache", namespace)
+	}
+
+	r.mu.Lock()
+	c, ok := r.caches[namespace]
+	if ok && c.expired(r.retryInterval) {
+		ok = false
+	}
+	if !ok {
+		c = &secretCache{done: make(chan struct{})}
+		r.caches[namespace] = c
+		go r.sync(namespace, c)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.lister, c.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to wait for Secret informer for namespace %q: %w", namespace, ctx.Err())
+	}
+}
+
+// expired reports whether c failed to sync more than retryInterval ago.
+func (c *secretCache) expired(retryInterval time.Duration) bool {
+	select {
+	case <-c.done:
+		return c.err != nil && time.Since(c.failedAt) >= retryInterval
+	default:
+		return false
+	}
+}
+
+// sync starts the informer of namespace and records the outcome of its initial
+// sync in c.
+func (r *cachedSecretReader) sync(namespace string, c *secretCache) {
+	defer close(c.done)
+
+	// The informer runs until the reader is done, or until its initial sync fails.
+	stopCh := make(chan struct{})
+	stopInformer := sync.OnceFunc(func() { close(stopCh) })
+	context.AfterFunc(r.ctx, stopInformer)
+
+	factory := informers.NewSharedInformerFactoryWithOptions(r.client, r.resync, informers.WithNamespace(namespace))
+	secrets := factory.Core().V1().Secrets()
+	informer := secrets.Informer()
+	factory.Start(stopCh)
+
+	// The sync is not bound to the reader that started it, since others may be waiting.
+	syncCtx, cancel := context.WithTimeout(r.ctx, r.syncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(syncCtx.Done(), informer.HasSynced) {
+		stopInformer()
+		factory.Shutdown()
+		c.err = fmt.Errorf("failed to sync Secret informer for namespace %q", namespace)
+		c.failedAt = time.Now()
+		return
+	}
+	c.lister = secrets.Lister().Secrets(namespace)
+}
@@ -0,0 +1,219 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+)
+
+// maxClockSkew is how far observation times may be ahead of the clock of the
+// webhook, to tolerate clock differences between cluster managers and the hub.
+const maxClockSkew = time.Minute
+
+// Reserved cluster extensions whose payloads are read by pkg/access.
+const (
+	clusterExecExtensionKey       = "client.authentication.k8s.io/exec"
+	additionalCLIArgsExtensionKey = "clusterprofiles.multicluster.x-k8s.io/exec/additional-args"
+	additionalEnvVarsExtensionKey = "clusterprofiles.multicluster.x-k8s.io/exec/additional-envs"
+)
+
+//+kubebuilder:webhook:path=/validate-multicluster-x-k8s-io-v1alpha1-clusterprofile,mutating=false,failurePolicy=fail,sideEffects=None,groups=multicluster.x-k8s.io,resources=clusterprofiles;clusterprofiles/status,verbs=create;update,versions=v1alpha1,name=vclusterprofile.multicluster.x-k8s.io,admissionReviewVersions=v1
+
+// ClusterProfileValidator validates ClusterProfiles, including updates of
+// their status subresource.
+type ClusterProfileValidator struct{}
+
+var _ admission.Validator[*v1alpha1.ClusterProfile] = &ClusterProfileValidator{}
+
+// ValidateCreate implements admission.Validator.
+func (v *ClusterProfileValidator) ValidateCreate(
+	_ context.Context, profile *v1alpha1.ClusterProfile,
+) (admission.Warnings, error) {
+	return clusterProfileWarnings(profile), invalid(
+		v1alpha1.ClusterProfileSchemeGroupVersionKind.GroupKind(), profile.Name, ValidateClusterProfile(profile))
+}
+
+// ValidateUpdate implements admission.Validator.
+func (v *ClusterProfileValidator) ValidateUpdate(
+	_ context.Context, old, profile *v1alpha1.ClusterProfile,
+) (admission.Warnings, error) {
+	return clusterProfileWarnings(profile), invalid(
+		v1alpha1.ClusterProfileSchemeGroupVersionKind.GroupKind(), profile.Name, ValidateClusterProfileUpdate(old, profile))
+}
+
+// ValidateDelete implements admission.Validator.
+func (v *ClusterProfileValidator) ValidateDelete(
+	context.Context, *v1alpha1.ClusterProfile,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateClusterProfile returns the rules that profile violates beyond its
+// CRD schema.
+func ValidateClusterProfile(profile *v1alpha1.ClusterProfile) field.ErrorList {
+	return validateClusterProfile(profile, nil)
+}
+
+// ValidateClusterProfileUpdate is ValidateClusterProfile for an update of old
+// to profile. It only reports violations in fields that the update changes, so
+// that objects stored before a rule existed can still be updated, and nothing
+// for an object that is being deleted, so that its finalizers can be removed.
+func ValidateClusterProfileUpdate(old, profile *v1alpha1.ClusterProfile) field.ErrorList {
+	if profile.DeletionTimestamp != nil {
+		return nil
+	}
+	return validateClusterProfile(profile, old)
+}
+
+// validateClusterProfile validates profile. If old is not nil, fields that are
+// unchanged from old are not validated.
+func validateClusterProfile(profile, old *v1alpha1.ClusterProfile) field.ErrorList {
+	if old == nil {
+		old = &v1alpha1.ClusterProfile{}
+	}
+	var errs field.ErrorList
+
+	label, ok := profile.Labels[v1alpha1.LabelClusterManagerKey]
+	oldLabel, oldOK := old.Labels[v1alpha1.LabelClusterManagerKey]
+	labelUnchanged := oldOK && oldLabel == label && old.Spec.ClusterManager.Name == profile.Spec.ClusterManager.Name
+	if ok && !labelUnchanged && label != profile.Spec.ClusterManager.Name {
+		errs = append(errs, field.Invalid(
+			field.NewPath("metadata", "labels").Key(v1alpha1.LabelClusterManagerKey), label,
+			fmt.Sprintf("must match spec.clusterManager.name %q", profile.Spec.ClusterManager.Name),
+		))
+	}
+
+	statusPath := field.NewPath("status")
+	latest := metav1.NewTime(time.Now().Add(maxClockSkew))
+	oldProperties := make(map[string]v1alpha1.Property, len(old.Status.Properties))
+	for _, p := range old.Status.Properties {
+		oldProperties[p.Name] = p
+	}
+	for i, p := range profile.Status.Properties {
+		if oldP, ok := oldProperties[p.Name]; ok && apiequality.Semantic.DeepEqual(oldP, p) {
+			continue
+		}
+		path := statusPath.Child("properties").Index(i)
+		errs = append(errs, validateObservedTime(p.LastObservedTime, latest, path.Child("lastObservedTime"))...)
+		// The CRD schema checks the other types, but CEL cannot parse JSON.
+		if msgs := v1alpha1.ValidatePropertyValue(p.Type, p.Value); len(msgs) > 0 {
+			errs = append(errs, field.Invalid(path.Child("value"), p.Value, strings.Join(msgs, "; ")))
+		}
+	}
+	if r := profile.Status.Resources; r != nil &&
+		(old.Status.Resources == nil || !old.Status.Resources.LastObservedTime.Equal(&r.LastObservedTime)) {
+		errs = append(errs, validateObservedTime(r.LastObservedTime, latest,
+			statusPath.Child("resources", "lastObservedTime"))...)
+	}
+
+	oldAccess := providersByName(old.Status.AccessProviders)
+	accessPath := statusPath.Child("accessProviders")
+	accessNames := make(map[string]bool, len(profile.Status.AccessProviders))
+	for i := range profile.Status.AccessProviders {
+		p := &profile.Status.AccessProviders[i]
+		accessNames[p.Name] = true
+		if !providerUnchanged(oldAccess, p) {
+			errs = append(errs, validateAccessProvider(p, accessPath.Index(i))...)
+		}
+	}
+
+	oldCredential := providersByName(old.Status.CredentialProviders)
+	credentialPath := statusPath.Child("credentialProviders")
+	for i := range profile.Status.CredentialProviders {
+		p := &profile.Status.CredentialProviders[i]
+		wasDuplicate := oldAccess[p.Name] != nil && oldCredential[p.Name] != nil
+		if accessNames[p.Name] && !wasDuplicate {
+			errs = append(errs, field.Duplicate(credentialPath.Index(i).Child("name"), p.Name))
+		}
+		if !providerUnchanged(oldCredential, p) {
+			errs = append(errs, validateAccessProvider(p, credentialPath.Index(i))...)
+		}
+	}
+	return errs
+}
+
+func providersByName(providers []v1alpha1.AccessProvider) map[string]*v1alpha1.AccessProvider {
+	byName := make(map[string]*v1alpha1.AccessProvider, len(providers))
+	for i := range providers {
+		byName[providers[i].Name] = &providers[i]
+	}
+	return byName
+}
+
+// providerUnchanged reports whether p is in old, unchanged.
+func providerUnchanged(old map[string]*v1alpha1.AccessProvider, p *v1alpha1.AccessProvider) bool {
+	oldP, ok := old[p.Name]
+	return ok && apiequality.Semantic.DeepEqual(oldP, p)
+}
+
+// validateObservedTime rejects observation times after latest, which would
+// make stale data look fresh to consumers until the clock catches up.
+func validateObservedTime(t, latest metav1.Time, path *field.Path) field.ErrorList {
+	if t.After(latest.Time) {
+		return field.ErrorList{field.Invalid(path, t.UTC().Format(time.RFC3339), "must not be in the future")}
+	}
+	return nil
+}
+
+func validateAccessProvider(p *v1alpha1.AccessProvider, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	extensionsPath := path.Child("cluster", "extensions")
+	for i, ext := range p.Cluster.Extensions {
+		extPath := extensionsPath.Index(i).Child("extension")
+		raw := ext.Extension.Raw
+		if len(raw) == 0 {
+			errs = append(errs, field.Required(extPath, "extension payload must not be empty"))
+			continue
+		}
+		var err error
+		switch ext.Name {
+		case clusterExecExtensionKey:
+			var config map[string]any
+			err = json.Unmarshal(raw, &config)
+		case additionalCLIArgsExtensionKey:
+			var args []string
+			err = yaml.Unmarshal(raw, &args)
+		case additionalEnvVarsExtensionKey:
+			var envVars map[string]string
+			err = yaml.Unmarshal(raw, &envVars)
+		default:
+			if !json.Valid(raw) {
+				err = errors.New("invalid JSON")
+			}
+		}
+		if err != nil {
+			errs = append(errs, field.Invalid(extPath, string(raw),
+				fmt.Sprintf("cannot parse payload of extension %q: %v", ext.Name, err)))
+		}
+	}
+	return errs
+}
+
+func clusterProfileWarnings(profile *v1alpha1.ClusterProfile) admission.Warnings {
+	if len(profile.Status.CredentialProviders) == 0 {
+		return nil
+	}
+	return admission.Warnings{"status.credentialProviders is deprecated; use status.accessProviders instead"}
+}
+
+// invalid returns an Invalid API error for the object name of kind gk, or nil
+// if errs is empty.
+func invalid(gk schema.GroupKind, name string, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(gk, name, errs)
+}
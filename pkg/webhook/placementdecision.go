@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+)
+
+//+kubebuilder:webhook:path=/validate-multicluster-x-k8s-io-v1alpha1-placementdecision,mutating=false,failurePolicy=fail,sideEffects=None,groups=multicluster.x-k8s.io,resources=placementdecisions,verbs=create;update,versions=v1alpha1,name=vplacementdecision.multicluster.x-k8s.io,admissionReviewVersions=v1
+
+// PlacementDecisionValidator validates PlacementDecisions.
+type PlacementDecisionValidator struct{}
+
+var _ admission.Validator[*v1alpha1.PlacementDecision] = &PlacementDecisionValidator{}
+
+// ValidateCreate implements admission.Validator.
+func (v *PlacementDecisionValidator) ValidateCreate(
+	_ context.Context, decision *v1alpha1.PlacementDecision,
+) (admission.Warnings, error) {
+	return nil, invalid(
+		v1alpha1.PlacementDecisionSchemeGroupVersionKind.GroupKind(), decision.Name, ValidatePlacementDecision(decision))
+}
+
+// ValidateUpdate implements admission.Validator.
+func (v *PlacementDecisionValidator) ValidateUpdate(
+	_ context.Context, old, decision *v1alpha1.PlacementDecision,
+) (admission.Warnings, error) {
+	return nil, invalid(
+		v1alpha1.PlacementDecisionSchemeGroupVersionKind.GroupKind(), decision.Name, ValidatePlacementDecisionUpdate(old, decision))
+}
+
+// ValidateDelete implements admission.Validator.
+func (v *PlacementDecisionValidator) ValidateDelete(
+	context.Context, *v1alpha1.PlacementDecision,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidatePlacementDecision returns the rules that decision violates beyond
+// its CRD schema. References with an empty namespace point to the namespace
+// of decision, so they duplicate references that spell it out.
+func ValidatePlacementDecision(decision *v1alpha1.PlacementDecision) field.ErrorList {
+	return validatePlacementDecision(decision, nil)
+}
+
+// ValidatePlacementDecisionUpdate is ValidatePlacementDecision for an update of
+// old to decision. Duplicate references that old already had are not reported,
+// and nothing is reported for a decision that is being deleted.
+func ValidatePlacementDecisionUpdate(old, decision *v1alpha1.PlacementDecision) field.ErrorList {
+	if decision.DeletionTimestamp != nil {
+		return nil
+	}
+	return validatePlacementDecision(decision, old)
+}
+
+func validatePlacementDecision(decision, old *v1alpha1.PlacementDecision) field.ErrorList {
+	var oldDuplicates map[v1alpha1.ClusterProfileReference]bool
+	if old != nil {
+		oldDuplicates = duplicateReferences(old)
+	}
+	var errs field.ErrorList
+	decisionsPath := field.NewPath("decisions")
+	seen := make(map[v1alpha1.ClusterProfileReference]bool, len(decision.Decisions))
+	for i, d := range decision.Decisions {
+		ref := resolveReference(decision, d.ClusterProfileRef)
+		if seen[ref] && !oldDuplicates[ref] {
+			errs = append(errs, field.Duplicate(decisionsPath.Index(i).Child("clusterProfileRef"), d.ClusterProfileRef))
+		}
+		seen[ref] = true
+	}
+	return errs
+}
+
+// duplicateReferences returns the references that decision lists more than once.
+func duplicateReferences(decision *v1alpha1.PlacementDecision) map[v1alpha1.ClusterProfileReference]bool {
+	seen := make(map[v1alpha1.ClusterProfileReference]bool, len(decision.Decisions))
+	duplicates := map[v1alpha1.ClusterProfileReference]bool{}
+	for _, d := range decision.Decisions {
+		ref := resolveReference(decision, d.ClusterProfileRef)
+		if seen[ref] {
+			duplicates[ref] = true
+		}
+		seen[ref] = true
+	}
+	return duplicates
+}
+
+// resolveReference returns ref with the namespace of decision if it has none.
+func resolveReference(decision *v1alpha1.PlacementDecision, ref v1alpha1.ClusterProfileReference) v1alpha1.ClusterProfileReference {
+	if ref.Namespace == "" {
+		ref.Namespace = decision.Namespace
+	}
+	return ref
+}
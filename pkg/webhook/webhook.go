@@ -0,0 +1,44 @@
+// Package webhook provides validating admission webhooks for the v1alpha1
+// ClusterProfile and PlacementDecision APIs.
+//
+// The CRD schemas cannot express rules that span several fields or that need
+// to parse opaque payloads. The webhooks enforce those rules, so that consumers
+// can rely on them instead of re-validating every object they read:
+//
+//   - the x-k8s.io/cluster-manager label of a ClusterProfile, if set, matches
+//     spec.clusterManager.name;
+//   - the reserved extensions of access providers hold parseable payloads;
+//   - a provider name is not listed in both AccessProviders and the deprecated
+//     CredentialProviders;
+//   - observation times of properties and resources are not in the future;
+//   - typed property values, including JSON documents, match their type;
+//   - a PlacementDecision does not reference the same ClusterProfile twice.
+//
+// The CRD schemas reject providers listed in both lists as well, and the
+// ValidatingAdmissionPolicy in config/policy, applied with the CRDs by
+// `make install`, requires the cluster manager label.
+// The manifests in config/webhook register the validating webhooks with the
+// API server. The generated CRDs do not serve v1alpha2; to serve it, mark the
+// version served and set the Webhook conversion strategy, pointing at the
+// /convert path of the same server, in the same change.
+package webhook
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+)
+
+// SetupWithManager registers the validating webhooks with the webhook server
+// of mgr. The scheme of mgr must include the v1alpha1 types. If it also
+// includes the v1alpha2 types, the conversion webhook is served at /convert.
+func SetupWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr, &v1alpha1.ClusterProfile{}).
+		WithValidator(&ClusterProfileValidator{}).
+		Complete(); err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr, &v1alpha1.PlacementDecision{}).
+		WithValidator(&PlacementDecisionValidator{}).
+		Complete()
+}
@@ -0,0 +1,303 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+)
+
+func TestWebhook(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "Webhook Suite")
+}
+
+func providerWithExtension(name, extension, payload string) v1alpha1.AccessProvider {
+	return v1alpha1.AccessProvider{
+		Name: name,
+		Cluster: clientcmdv1.Cluster{
+			Server: "https://spoke",
+			Extensions: []clientcmdv1.NamedExtension{{
+				Name:      extension,
+				Extension: runtime.RawExtension{Raw: []byte(payload)},
+			}},
+		},
+	}
+}
+
+func errorFields(errs field.ErrorList) []string {
+	fields := make([]string, 0, len(errs))
+	for _, err := range errs {
+		fields = append(fields, err.Field)
+	}
+	return fields
+}
+
+var _ = ginkgo.Describe("ClusterProfile validation", func() {
+	var profile *v1alpha1.ClusterProfile
+
+	ginkgo.BeforeEach(func() {
+		profile = &v1alpha1.ClusterProfile{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "spoke-1",
+				Labels: map[string]string{v1alpha1.LabelClusterManagerKey: "fleet"},
+			},
+			Spec: v1alpha1.ClusterProfileSpec{ClusterManager: v1alpha1.ClusterManager{Name: "fleet"}},
+			Status: v1alpha1.ClusterProfileStatus{
+				AccessProviders: []v1alpha1.AccessProvider{
+					providerWithExtension("secretreader", clusterExecExtensionKey, `{"clusterName":"spoke-1"}`),
+					providerWithExtension("exec", additionalCLIArgsExtensionKey, `["--verbose"]`),
+					providerWithExtension("env", additionalEnvVarsExtensionKey, `{"REGION":"eu"}`),
+				},
+			},
+		}
+	})
+
+	ginkgo.It("should accept a valid ClusterProfile", func() {
+		gomega.Expect(ValidateClusterProfile(profile)).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should accept a ClusterProfile without the cluster manager label", func() {
+		profile.Labels = nil
+		gomega.Expect(ValidateClusterProfile(profile)).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should reject a cluster manager label that does not match the spec", func() {
+		profile.Labels[v1alpha1.LabelClusterManagerKey] = "other"
+		gomega.Expect(errorFields(ValidateClusterProfile(profile))).
+			To(gomega.ConsistOf("metadata.labels[x-k8s.io/cluster-manager]"))
+	})
+
+	ginkgo.It("should reject unparseable reserved extension payloads", func() {
+		profile.Status.AccessProviders = []v1alpha1.AccessProvider{
+			providerWithExtension("a", clusterExecExtensionKey, `["not","an","object"]`),
+			providerWithExtension("b", additionalCLIArgsExtensionKey, `{"not":"a list"}`),
+			providerWithExtension("c", additionalEnvVarsExtensionKey, `["not a map"]`),
+			providerWithExtension("d", "example.com/custom", `{"unterminated"`),
+			providerWithExtension("e", "example.com/custom", ``),
+		}
+		gomega.Expect(errorFields(ValidateClusterProfile(profile))).To(gomega.ConsistOf(
+			"status.accessProviders[0].cluster.extensions[0].extension",
+			"status.accessProviders[1].cluster.extensions[0].extension",
+			"status.accessProviders[2].cluster.extensions[0].extension",
+			"status.accessProviders[3].cluster.extensions[0].extension",
+			"status.accessProviders[4].cluster.extensions[0].extension",
+		))
+	})
+
+	ginkgo.It("should validate the extensions of credential providers", func() {
+		profile.Status.CredentialProviders = []v1alpha1.AccessProvider{
+			providerWithExtension("legacy", additionalCLIArgsExtensionKey, `{}`),
+		}
+		gomega.Expect(errorFields(ValidateClusterProfile(profile))).To(gomega.ConsistOf(
+			"status.credentialProviders[0].cluster.extensions[0].extension",
+		))
+	})
+
+	ginkgo.It("should reject a provider listed in both provider lists", func() {
+		profile.Status.CredentialProviders = []v1alpha1.AccessProvider{
+			{Name: "legacy"},
+			{Name: "secretreader"},
+		}
+		gomega.Expect(errorFields(ValidateClusterProfile(profile))).
+			To(gomega.ConsistOf("status.credentialProviders[1].name"))
+	})
+
+	ginkgo.It("should reject observation times in the future", func() {
+		future := metav1.NewTime(time.Now().Add(time.Hour))
+		profile.Status.Properties = []v1alpha1.Property{
+			{Name: "a", Value: "1", LastObservedTime: metav1.Now()},
+			{Name: "b", Value: "2", LastObservedTime: future},
+		}
+		profile.Status.Resources = &v1alpha1.ResourceSummary{LastObservedTime: future}
+		gomega.Expect(errorFields(ValidateClusterProfile(profile))).To(gomega.ConsistOf(
+			"status.properties[1].lastObservedTime",
+			"status.resources.lastObservedTime",
+		))
+	})
+
+	ginkgo.It("should reject values that do not match their declared type", func() {
+		profile.Status.Properties = []v1alpha1.Property{
+			{Name: "a", Value: `{"gpus":8}`, Type: v1alpha1.PropertyValueTypeJSON},
+			{Name: "b", Value: `{"gpus":`, Type: v1alpha1.PropertyValueTypeJSON},
+			{Name: "c", Value: "99999999999999999999", Type: v1alpha1.PropertyValueTypeInteger},
+			{Name: "d", Value: "not typed"},
+		}
+		gomega.Expect(errorFields(ValidateClusterProfile(profile))).To(gomega.ConsistOf(
+			"status.properties[1].value",
+			"status.properties[2].value",
+		))
+	})
+
+	ginkgo.It("should tolerate small clock differences", func() {
+		profile.Status.Properties = []v1alpha1.Property{
+			{Name: "a", Value: "1", LastObservedTime: metav1.NewTime(time.Now().Add(maxClockSkew / 2))},
+		}
+		gomega.Expect(ValidateClusterProfile(profile)).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should return an Invalid error and warn about credential providers", func() {
+		v := &ClusterProfileValidator{}
+		profile.Status.CredentialProviders = []v1alpha1.AccessProvider{{Name: "legacy"}}
+		warnings, err := v.ValidateCreate(context.Background(), profile)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(warnings).To(gomega.HaveLen(1))
+
+		updated := profile.DeepCopy()
+		updated.Labels[v1alpha1.LabelClusterManagerKey] = "other"
+		_, err = v.ValidateUpdate(context.Background(), profile, updated)
+		gomega.Expect(apierrors.IsInvalid(err)).To(gomega.BeTrue())
+	})
+})
+
+var _ = ginkgo.Describe("ClusterProfile update validation", func() {
+	var stored *v1alpha1.ClusterProfile
+
+	// stored was written before the webhook existed and violates every rule.
+	ginkgo.BeforeEach(func() {
+		stored = &v1alpha1.ClusterProfile{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "spoke-1",
+				Labels: map[string]string{v1alpha1.LabelClusterManagerKey: "other"},
+			},
+			Spec: v1alpha1.ClusterProfileSpec{ClusterManager: v1alpha1.ClusterManager{Name: "fleet"}},
+			Status: v1alpha1.ClusterProfileStatus{
+				Properties: []v1alpha1.Property{
+					{Name: "a", Value: "{", Type: v1alpha1.PropertyValueTypeJSON},
+					{Name: "b", Value: "1", LastObservedTime: metav1.NewTime(time.Now().Add(time.Hour))},
+				},
+				Resources: &v1alpha1.ResourceSummary{LastObservedTime: metav1.NewTime(time.Now().Add(time.Hour))},
+				AccessProviders: []v1alpha1.AccessProvider{
+					providerWithExtension("secretreader", clusterExecExtensionKey, `[`),
+				},
+				CredentialProviders: []v1alpha1.AccessProvider{{Name: "secretreader"}},
+			},
+		}
+	})
+
+	ginkgo.It("should accept updates that leave invalid fields unchanged", func() {
+		updated := stored.DeepCopy()
+		updated.Finalizers = nil
+		updated.Labels["team"] = "platform"
+		updated.Status.Properties = append(updated.Status.Properties, v1alpha1.Property{Name: "c", Value: "2"})
+		// Reordering keeps the same properties and providers.
+		updated.Status.Properties[0], updated.Status.Properties[1] = updated.Status.Properties[1], updated.Status.Properties[0]
+		gomega.Expect(ValidateClusterProfileUpdate(stored, updated)).To(gomega.BeEmpty())
+
+		_, err := (&ClusterProfileValidator{}).ValidateUpdate(context.Background(), stored, updated)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	ginkgo.It("should reject changes to fields that are still invalid", func() {
+		updated := stored.DeepCopy()
+		updated.Labels[v1alpha1.LabelClusterManagerKey] = "another"
+		updated.Status.Properties[0].Value = "{{"
+		updated.Status.Properties[1].LastObservedTime = metav1.NewTime(time.Now().Add(2 * time.Hour))
+		updated.Status.AccessProviders[0].Cluster.Server = "https://spoke-2"
+		gomega.Expect(errorFields(ValidateClusterProfileUpdate(stored, updated))).To(gomega.ConsistOf(
+			"metadata.labels[x-k8s.io/cluster-manager]",
+			"status.properties[0].value",
+			"status.properties[1].lastObservedTime",
+			"status.accessProviders[0].cluster.extensions[0].extension",
+		))
+	})
+
+	ginkgo.It("should reject new duplicates of existing providers", func() {
+		stored.Status.CredentialProviders = nil
+		updated := stored.DeepCopy()
+		updated.Status.CredentialProviders = []v1alpha1.AccessProvider{{Name: "secretreader"}}
+		gomega.Expect(errorFields(ValidateClusterProfileUpdate(stored, updated))).To(
+			gomega.ConsistOf("status.credentialProviders[0].name"))
+	})
+
+	ginkgo.It("should not validate objects that are being deleted", func() {
+		updated := stored.DeepCopy()
+		updated.DeletionTimestamp = ptr.To(metav1.Now())
+		updated.Finalizers = nil
+		updated.Labels[v1alpha1.LabelClusterManagerKey] = "another"
+		gomega.Expect(ValidateClusterProfileUpdate(stored, updated)).To(gomega.BeEmpty())
+	})
+})
+
+var _ = ginkgo.Describe("PlacementDecision validation", func() {
+	decision := func(refs ...v1alpha1.ClusterProfileReference) *v1alpha1.PlacementDecision {
+		d := &v1alpha1.PlacementDecision{ObjectMeta: metav1.ObjectMeta{Name: "decision", Namespace: "fleet"}}
+		for _, ref := range refs {
+			d.Decisions = append(d.Decisions, v1alpha1.ClusterDecision{ClusterProfileRef: ref})
+		}
+		return d
+	}
+
+	ginkgo.It("should accept distinct references", func() {
+		d := decision(
+			v1alpha1.ClusterProfileReference{Name: "spoke-1"},
+			v1alpha1.ClusterProfileReference{Name: "spoke-2"},
+			v1alpha1.ClusterProfileReference{Name: "spoke-1", Namespace: "other"},
+		)
+		gomega.Expect(ValidatePlacementDecision(d)).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should reject duplicate references", func() {
+		d := decision(
+			v1alpha1.ClusterProfileReference{Name: "spoke-1"},
+			v1alpha1.ClusterProfileReference{Name: "spoke-2"},
+			v1alpha1.ClusterProfileReference{Name: "spoke-1", Namespace: "fleet"},
+			v1alpha1.ClusterProfileReference{Name: "spoke-2"},
+		)
+		gomega.Expect(errorFields(ValidatePlacementDecision(d))).To(gomega.ConsistOf(
+			"decisions[2].clusterProfileRef",
+			"decisions[3].clusterProfileRef",
+		))
+
+		_, err := (&PlacementDecisionValidator{}).ValidateCreate(context.Background(), d)
+		gomega.Expect(apierrors.IsInvalid(err)).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("should only reject duplicates added by an update", func() {
+		stored := decision(
+			v1alpha1.ClusterProfileReference{Name: "spoke-1"},
+			v1alpha1.ClusterProfileReference{Name: "spoke-1"},
+		)
+		updated := stored.DeepCopy()
+		updated.Decisions = append(updated.Decisions, v1alpha1.ClusterDecision{
+			ClusterProfileRef: v1alpha1.ClusterProfileReference{Name: "spoke-2"},
+		})
+		gomega.Expect(ValidatePlacementDecisionUpdate(stored, updated)).To(gomega.BeEmpty())
+
+		updated.Decisions = append(updated.Decisions, v1alpha1.ClusterDecision{
+			ClusterProfileRef: v1alpha1.ClusterProfileReference{Name: "spoke-2", Namespace: "fleet"},
+		})
+		gomega.Expect(errorFields(ValidatePlacementDecisionUpdate(stored, updated))).To(
+			gomega.ConsistOf("decisions[3].clusterProfileRef"))
+
+		updated.DeletionTimestamp = ptr.To(metav1.Now())
+		_, err := (&PlacementDecisionValidator{}).ValidateUpdate(context.Background(), stored, updated)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+})
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"sigs.k8s.io/cluster-inventory-api/pkg/credentialplugin"
+)
+
+const usage = `Usage:
+  credential-envelope keygen --public-key=FILE --private-key=FILE
+  credential-envelope encrypt --public-key=FILE [--in=FILE]
+
+keygen writes a new key pair. Mount the private key into the consumer with
+--decryption-key-dir; the public key is used by whoever writes Secrets.
+
+encrypt reads a token or kubeconfig from --in (or stdin) and writes the
+encrypted value to stdout, ready to be stored as a Secret value.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	var err error
+	switch os.Args[1] {
+	case "keygen":
+		err = keygen(os.Args[2:])
+	case "encrypt":
+		err = encrypt(os.Args[2:], os.Stdin, os.Stdout)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "[credential-envelope] "+err.Error())
+		os.Exit(1)
+	}
+}
+
+func keygen(args []string) error {
+	fs := flag.NewFlagSet("keygen", flag.ExitOnError)
+	publicKeyFile := fs.String("public-key", "", "File to write the public key to (required).")
+	privateKeyFile := fs.String("private-key", "", "File to write the private key to (required).")
+	_ = fs.Parse(args)
+	if *publicKeyFile == "" || *privateKeyFile == "" {
+		return errors.New("keygen requires --public-key and --private-key")
+	}
+
+	publicKey, privateKey, err := credentialplugin.GenerateEnvelopeKey()
+	if err != nil {
+		return fmt.Errorf("failed to generate key: %w", err)
+	}
+	// O_EXCL so that an existing private key is never overwritten by accident
+	f, err := os.OpenFile(*privateKeyFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(f, privateKey); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.WriteFile(*publicKeyFile, []byte(publicKey+"\n"), 0o644); err != nil {
+		return err
+	}
+
+	keyID, err := credentialplugin.EnvelopeKeyID(publicKey)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "key ID "+keyID)
+	return nil
+}
+
+func encrypt(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("encrypt", flag.ExitOnError)
+	publicKeyFile := fs.String("public-key", "", "File holding the public key to encrypt for (required).")
+	in := fs.String("in", "", "File to encrypt. Defaults to stdin.")
+	_ = fs.Parse(args)
+	if *publicKeyFile == "" {
+		return errors.New("encrypt requires --public-key")
+	}
+
+	publicKey, err := os.ReadFile(*publicKeyFile)
+	if err != nil {
+		return err
+	}
+	var plaintext []byte
+	if *in == "" {
+		plaintext, err = io.ReadAll(stdin)
+	} else {
+		plaintext, err = os.ReadFile(*in)
+	}
+	if err != nil {
+		return err
+	}
+	if len(plaintext) == 0 {
+		return errors.New("nothing to encrypt")
+	}
+
+	sealed, err := credentialplugin.SealEnvelope(plaintext, string(publicKey))
+	if err != nil {
+		return fmt.Errorf("failed to encrypt: %w", err)
+	}
+	_, err = stdout.Write(sealed)
+	return err
+}
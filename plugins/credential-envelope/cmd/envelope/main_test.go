@@ -0,0 +1,98 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-inventory-api/pkg/credentialplugin"
+)
+
+func TestCredentialEnvelope(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "Credential Envelope Suite")
+}
+
+var _ = ginkgo.Describe("credential-envelope", func() {
+	var (
+		tempDir        string
+		keyDir         string
+		publicKeyFile  string
+		privateKeyFile string
+	)
+
+	ginkgo.BeforeEach(func() {
+		tempDir = ginkgo.GinkgoT().TempDir()
+		// The private key is mounted into the consumer on its own
+		keyDir = filepath.Join(tempDir, "keys")
+		gomega.Expect(os.Mkdir(keyDir, 0o700)).To(gomega.Succeed())
+		publicKeyFile = filepath.Join(tempDir, "hub.pub")
+		privateKeyFile = filepath.Join(keyDir, "hub")
+		gomega.Expect(keygen([]string{
+			"--public-key=" + publicKeyFile,
+			"--private-key=" + privateKeyFile,
+		})).To(gomega.Succeed())
+	})
+
+	ginkgo.It("should write a private key that only its owner can read", func() {
+		info, err := os.Stat(privateKeyFile)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(info.Mode().Perm()).To(gomega.Equal(os.FileMode(0o600)))
+	})
+
+	ginkgo.It("should refuse to overwrite a private key", func() {
+		err := keygen([]string{"--public-key=" + publicKeyFile, "--private-key=" + privateKeyFile})
+		gomega.Expect(err).To(gomega.MatchError(os.ErrExist))
+	})
+
+	ginkgo.It("should encrypt stdin for the generated key", func() {
+		var out bytes.Buffer
+		gomega.Expect(encrypt([]string{"--public-key=" + publicKeyFile}, strings.NewReader("spoke-token"), &out)).
+			To(gomega.Succeed())
+		gomega.Expect(out.String()).To(gomega.HavePrefix(credentialplugin.EnvelopePrefix))
+		gomega.Expect(out.String()).NotTo(gomega.ContainSubstring("spoke-token"))
+
+		plaintext, err := credentialplugin.EnvelopeOptions{KeyDir: keyDir}.Open(out.Bytes())
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(string(plaintext)).To(gomega.Equal("spoke-token"))
+	})
+
+	ginkgo.It("should encrypt the file named by --in", func() {
+		in := filepath.Join(tempDir, "kubeconfig")
+		gomega.Expect(os.WriteFile(in, []byte("apiVersion: v1\nkind: Config\n"), 0o600)).To(gomega.Succeed())
+		var out bytes.Buffer
+		gomega.Expect(encrypt([]string{"--public-key=" + publicKeyFile, "--in=" + in}, strings.NewReader(""), &out)).
+			To(gomega.Succeed())
+
+		plaintext, err := credentialplugin.EnvelopeOptions{KeyDir: keyDir}.Open(out.Bytes())
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(string(plaintext)).To(gomega.Equal("apiVersion: v1\nkind: Config\n"))
+	})
+
+	ginkgo.It("should reject empty input", func() {
+		var out bytes.Buffer
+		err := encrypt([]string{"--public-key=" + publicKeyFile}, strings.NewReader(""), &out)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("nothing to encrypt")))
+		gomega.Expect(out.Len()).To(gomega.BeZero())
+	})
+})
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+	"sigs.k8s.io/cluster-inventory-api/pkg/credentialplugin"
+)
+
+// ProviderName is the name of the credential provider.
+const ProviderName = "eks"
+
+const (
+	// tokenPrefix marks a presigned GetCallerIdentity URL as an EKS bearer token.
+	tokenPrefix = "k8s-aws-v1."
+	// clusterIDHeader binds the presigned request to one cluster.
+	clusterIDHeader = "x-k8s-aws-id"
+	// presignExpiry is the X-Amz-Expires of the presigned request.
+	presignExpiry = 60 * time.Second
+	// tokenLifetime is how long EKS accepts a token after it was signed.
+	tokenLifetime = 15 * time.Minute
+	// tokenExpirySkew is subtracted from tokenLifetime, matching aws-iam-authenticator.
+	tokenExpirySkew = time.Minute
+	// maxResponseSize bounds the size of STS responses.
+	maxResponseSize = 1 << 20
+)
+
+// regionPattern matches AWS region names; the region ends up in a hostname.
+var regionPattern = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-\d+$`)
+
+type Provider struct {
+	// STSEndpoint, if set, overrides the regional STS endpoint
+	// https://sts.<region>.amazonaws.com, e.g. for FIPS or VPC endpoints.
+	STSEndpoint string
+	// HTTPClient is used for AssumeRoleWithWebIdentity. Defaults to a client with credentialplugin.HTTPTimeout.
+	HTTPClient *http.Client
+}
+
+type execClusterConfig struct {
+	ClusterName string `json:"clusterName"` // EKS cluster name (required)
+	Region      string `json:"region"`      // AWS region of the cluster (required)
+}
+
+func (Provider) Name() string { return ProviderName }
+
+func (p Provider) GetToken(
+	ctx context.Context,
+	info clientauthenticationv1.ExecCredential,
+) (clientauthenticationv1.ExecCredentialStatus, error) {
+	// Validate presence of cluster config
+	if info.Spec.Cluster == nil || len(info.Spec.Cluster.Config.Raw) == 0 {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("missing ExecCredential.Spec.Cluster.Config")
+	}
+	var cfg execClusterConfig
+	if err := json.Unmarshal(info.Spec.Cluster.Config.Raw, &cfg); err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{},
+			fmt.Errorf("invalid ExecCredential.Spec.Cluster.Config: %w", err)
+	}
+	if cfg.ClusterName == "" {
+		return clientauthenticationv1.ExecCredentialStatus{},
+			fmt.Errorf("missing clusterName in ExecCredential.Spec.Cluster.Config")
+	}
+	if !regionPattern.MatchString(cfg.Region) {
+		return clientauthenticationv1.ExecCredentialStatus{},
+			fmt.Errorf("invalid region %q in ExecCredential.Spec.Cluster.Config", cfg.Region)
+	}
+
+	endpoint := p.endpoint(cfg.Region)
+	creds, err := p.credentials(ctx, endpoint)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, err
+	}
+
+	now := time.Now()
+	token, err := presignToken(endpoint, cfg.ClusterName, cfg.Region, creds, now)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, err
+	}
+
+	// The token cannot outlive the credentials that signed it
+	expiry := now.Add(tokenLifetime)
+	if !creds.Expiration.IsZero() && creds.Expiration.Before(expiry) {
+		expiry = creds.Expiration
+	}
+
+	return clientauthenticationv1.ExecCredentialStatus{
+		Token:               token,
+		ExpirationTimestamp: credentialplugin.ExpirationTimestamp(expiry, tokenExpirySkew),
+	}, nil
+}
+
+// endpoint returns the STS endpoint for region.
+func (p Provider) endpoint(region string) string {
+	if p.STSEndpoint != "" {
+		return strings.TrimSuffix(p.STSEndpoint, "/")
+	}
+	domain := "amazonaws.com"
+	if strings.HasPrefix(region, "cn-") {
+		domain = "amazonaws.com.cn"
+	}
+	return "https://sts." + region + "." + domain
+}
+
+// presignToken presigns an STS GetCallerIdentity request for clusterName and
+// encodes its URL as an EKS bearer token.
+func presignToken(endpoint, clusterName, region string, creds awsCredentials, now time.Time) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, endpoint+"/?Action=GetCallerIdentity&Version=2011-06-15", nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build GetCallerIdentity request: %w", err)
+	}
+	req.Header.Set(clusterIDHeader, clusterName)
+	presigned, err := presignURL(req, creds, region, "sts", emptyPayloadHash, presignExpiry, now)
+	if err != nil {
+		return "", fmt.Errorf("failed to presign GetCallerIdentity request: %w", err)
+	}
+	return tokenPrefix + base64.RawURLEncoding.EncodeToString([]byte(presigned)), nil
+}
+
+// credentials resolves AWS credentials from the environment, either static
+// keys or a web identity token exchanged with AssumeRoleWithWebIdentity.
+func (p Provider) credentials(ctx context.Context, endpoint string) (awsCredentials, error) {
+	if id, secret := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"); id != "" || secret != "" {
+		if id == "" || secret == "" {
+			return awsCredentials{}, fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set together")
+		}
+		return awsCredentials{AccessKeyID: id, SecretAccessKey: secret, SessionToken: os.Getenv("AWS_SESSION_TOKEN")}, nil
+	}
+
+	tokenFile, roleARN := os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE"), os.Getenv("AWS_ROLE_ARN")
+	if tokenFile == "" || roleARN == "" {
+		return awsCredentials{}, fmt.Errorf("no AWS credentials found; set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY, " +
+			"or AWS_WEB_IDENTITY_TOKEN_FILE and AWS_ROLE_ARN")
+	}
+	token, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return awsCredentials{}, fmt.Errorf("failed to read web identity token: %w", err)
+	}
+	sessionName := os.Getenv("AWS_ROLE_SESSION_NAME")
+	if sessionName == "" {
+		sessionName = "cluster-inventory-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+	return p.assumeRoleWithWebIdentity(ctx, endpoint, roleARN, sessionName, strings.TrimSpace(string(token)))
+}
+
+// assumeRoleWithWebIdentityResponse is the subset of the STS response used here.
+type assumeRoleWithWebIdentityResponse struct {
+	Credentials struct {
+		AccessKeyID     string    `xml:"AccessKeyId"`
+		SecretAccessKey string    `xml:"SecretAccessKey"`
+		SessionToken    string    `xml:"SessionToken"`
+		Expiration      time.Time `xml:"Expiration"`
+	} `xml:"AssumeRoleWithWebIdentityResult>Credentials"`
+}
+
+// stsErrorResponse is the error response of the STS query API.
+type stsErrorResponse struct {
+	Code    string `xml:"Error>Code"`
+	Message string `xml:"Error>Message"`
+}
+
+// assumeRoleWithWebIdentity exchanges a web identity token for temporary
+// credentials. The request itself is not signed.
+func (p Provider) assumeRoleWithWebIdentity(
+	ctx context.Context,
+	endpoint, roleARN, sessionName, token string,
+) (awsCredentials, error) {
+	form := url.Values{
+		"Action":           {"AssumeRoleWithWebIdentity"},
+		"Version":          {"2011-06-15"},
+		"RoleArn":          {roleARN},
+		"RoleSessionName":  {sessionName},
+		"WebIdentityToken": {token},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/", strings.NewReader(form.Encode()))
+	if err != nil {
+		return awsCredentials{}, fmt.Errorf("failed to build AssumeRoleWithWebIdentity request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	client := p.HTTPClient
+	if client == nil {
+		client = credentialplugin.NewHTTPClient(nil)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return awsCredentials{}, fmt.Errorf("AssumeRoleWithWebIdentity request to %s failed: %w", endpoint, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return awsCredentials{}, fmt.Errorf("failed to read AssumeRoleWithWebIdentity response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var e stsErrorResponse
+		if xml.Unmarshal(body, &e) == nil && e.Code != "" {
+			return awsCredentials{}, fmt.Errorf("AssumeRoleWithWebIdentity for %s failed: %s: %s", roleARN, e.Code, e.Message)
+		}
+		return awsCredentials{}, fmt.Errorf("AssumeRoleWithWebIdentity for %s failed: unexpected status %s", roleARN, resp.Status)
+	}
+	var out assumeRoleWithWebIdentityResponse
+	if err := xml.Unmarshal(body, &out); err != nil {
+		return awsCredentials{}, fmt.Errorf("failed to parse AssumeRoleWithWebIdentity response: %w", err)
+	}
+	c := out.Credentials
+	if c.AccessKeyID == "" || c.SecretAccessKey == "" {
+		return awsCredentials{}, fmt.Errorf("AssumeRoleWithWebIdentity response has no credentials")
+	}
+	return awsCredentials{
+		AccessKeyID:     c.AccessKeyID,
+		SecretAccessKey: c.SecretAccessKey,
+		SessionToken:    c.SessionToken,
+		Expiration:      c.Expiration,
+	}, nil
+}
+
+func main() {
+	stsEndpoint := flag.String("sts-endpoint", "",
+		"STS endpoint URL overriding https://sts.<region>.amazonaws.com, e.g. for FIPS or VPC endpoints.")
+	flag.Parse()
+
+	credentialplugin.Run(Provider{STSEndpoint: *stsEndpoint})
+}
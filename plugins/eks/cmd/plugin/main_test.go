@@ -0,0 +1,193 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+)
+
+func TestEKS(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "EKS Plugin Suite")
+}
+
+func execInfo(config string) clientauthenticationv1.ExecCredential {
+	return clientauthenticationv1.ExecCredential{
+		Spec: clientauthenticationv1.ExecCredentialSpec{
+			Cluster: &clientauthenticationv1.Cluster{
+				Server: "https://ABCDEF.gr7.us-west-2.eks.amazonaws.com",
+				Config: runtime.RawExtension{Raw: []byte(config)},
+			},
+		},
+	}
+}
+
+// decodeToken returns the presigned URL inside an EKS token.
+func decodeToken(token string) *url.URL {
+	gomega.Expect(token).To(gomega.HavePrefix(tokenPrefix))
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(token, tokenPrefix))
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	u, err := url.Parse(string(raw))
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	return u
+}
+
+// clearAWSEnv unsets the credential variables for the current spec.
+func clearAWSEnv() {
+	for _, name := range []string{
+		"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN",
+		"AWS_WEB_IDENTITY_TOKEN_FILE", "AWS_ROLE_ARN", "AWS_ROLE_SESSION_NAME",
+	} {
+		ginkgo.GinkgoT().Setenv(name, "")
+	}
+}
+
+var _ = ginkgo.Describe("presignToken", func() {
+	ginkgo.It("should produce a stable token for fixed inputs", func() {
+		creds := awsCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+		now := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+
+		token, err := presignToken("https://sts.us-west-2.amazonaws.com", "spoke-1", "us-west-2", creds, now)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		again, err := presignToken("https://sts.us-west-2.amazonaws.com", "spoke-1", "us-west-2", creds, now)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(again).To(gomega.Equal(token))
+
+		u := decodeToken(token)
+		gomega.Expect(u.Host).To(gomega.Equal("sts.us-west-2.amazonaws.com"))
+		q := u.Query()
+		gomega.Expect(q.Get("Action")).To(gomega.Equal("GetCallerIdentity"))
+		gomega.Expect(q.Get("X-Amz-Credential")).To(gomega.Equal("AKIDEXAMPLE/20150830/us-west-2/sts/aws4_request"))
+		gomega.Expect(q.Get("X-Amz-Date")).To(gomega.Equal("20150830T123600Z"))
+		gomega.Expect(q.Get("X-Amz-Expires")).To(gomega.Equal("60"))
+		gomega.Expect(q.Get("X-Amz-SignedHeaders")).To(gomega.Equal("host;x-k8s-aws-id"))
+		gomega.Expect(q.Get("X-Amz-Signature")).To(gomega.MatchRegexp(`^[0-9a-f]{64}$`))
+
+		other, err := presignToken("https://sts.us-west-2.amazonaws.com", "spoke-2", "us-west-2", creds, now)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(decodeToken(other).Query().Get("X-Amz-Signature")).NotTo(gomega.Equal(q.Get("X-Amz-Signature")))
+	})
+})
+
+var _ = ginkgo.Describe("Provider", func() {
+	ginkgo.BeforeEach(clearAWSEnv)
+
+	ginkgo.It("should sign with static credentials from the environment", func() {
+		ginkgo.GinkgoT().Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+		ginkgo.GinkgoT().Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+		ginkgo.GinkgoT().Setenv("AWS_SESSION_TOKEN", "session")
+
+		start := time.Now()
+		status, err := Provider{}.GetToken(context.Background(), execInfo(`{"clusterName": "spoke-1", "region": "us-west-2"}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		u := decodeToken(status.Token)
+		gomega.Expect(u.Host).To(gomega.Equal("sts.us-west-2.amazonaws.com"))
+		gomega.Expect(u.Query().Get("X-Amz-Security-Token")).To(gomega.Equal("session"))
+		gomega.Expect(status.ExpirationTimestamp).NotTo(gomega.BeNil())
+		gomega.Expect(status.ExpirationTimestamp.Time).To(gomega.BeTemporally("~", start.Add(14*time.Minute), 5*time.Second))
+	})
+
+	ginkgo.It("should use the China partition endpoint for cn- regions", func() {
+		ginkgo.GinkgoT().Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+		ginkgo.GinkgoT().Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+		status, err := Provider{}.GetToken(context.Background(), execInfo(`{"clusterName": "spoke-1", "region": "cn-north-1"}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(decodeToken(status.Token).Host).To(gomega.Equal("sts.cn-north-1.amazonaws.com.cn"))
+	})
+
+	ginkgo.It("should exchange a web identity token for temporary credentials", func() {
+		expiration := time.Now().Add(5 * time.Minute).UTC().Truncate(time.Second)
+		var form url.Values
+		sts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = r.ParseForm()
+			form = r.PostForm
+			_, _ = w.Write([]byte(`<AssumeRoleWithWebIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleWithWebIdentityResult>
+    <Credentials>
+      <AccessKeyId>ASIAEXAMPLE</AccessKeyId>
+      <SecretAccessKey>temporary-secret</SecretAccessKey>
+      <SessionToken>temporary-session</SessionToken>
+      <Expiration>` + expiration.Format(time.RFC3339) + `</Expiration>
+    </Credentials>
+  </AssumeRoleWithWebIdentityResult>
+</AssumeRoleWithWebIdentityResponse>`))
+		}))
+		ginkgo.DeferCleanup(sts.Close)
+
+		tokenFile := filepath.Join(ginkgo.GinkgoT().TempDir(), "token")
+		gomega.Expect(os.WriteFile(tokenFile, []byte("web-identity\n"), 0o600)).To(gomega.Succeed())
+		ginkgo.GinkgoT().Setenv("AWS_WEB_IDENTITY_TOKEN_FILE", tokenFile)
+		ginkgo.GinkgoT().Setenv("AWS_ROLE_ARN", "arn:aws:iam::123456789012:role/hub")
+
+		p := Provider{STSEndpoint: sts.URL, HTTPClient: sts.Client()}
+		status, err := p.GetToken(context.Background(), execInfo(`{"clusterName": "spoke-1", "region": "us-west-2"}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		gomega.Expect(form.Get("Action")).To(gomega.Equal("AssumeRoleWithWebIdentity"))
+		gomega.Expect(form.Get("RoleArn")).To(gomega.Equal("arn:aws:iam::123456789012:role/hub"))
+		gomega.Expect(form.Get("WebIdentityToken")).To(gomega.Equal("web-identity"))
+
+		q := decodeToken(status.Token).Query()
+		gomega.Expect(q.Get("X-Amz-Credential")).To(gomega.HavePrefix("ASIAEXAMPLE/"))
+		gomega.Expect(q.Get("X-Amz-Security-Token")).To(gomega.Equal("temporary-session"))
+		// The token expires with the temporary credentials
+		gomega.Expect(status.ExpirationTimestamp.Time).To(gomega.BeTemporally("==", expiration.Add(-time.Minute)))
+	})
+
+	ginkgo.It("should report STS errors", func() {
+		sts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`<ErrorResponse><Error><Type>Sender</Type><Code>AccessDenied</Code>` +
+				`<Message>Not authorized</Message></Error></ErrorResponse>`))
+		}))
+		ginkgo.DeferCleanup(sts.Close)
+
+		tokenFile := filepath.Join(ginkgo.GinkgoT().TempDir(), "token")
+		gomega.Expect(os.WriteFile(tokenFile, []byte("web-identity"), 0o600)).To(gomega.Succeed())
+		ginkgo.GinkgoT().Setenv("AWS_WEB_IDENTITY_TOKEN_FILE", tokenFile)
+		ginkgo.GinkgoT().Setenv("AWS_ROLE_ARN", "arn:aws:iam::123456789012:role/hub")
+
+		p := Provider{STSEndpoint: sts.URL, HTTPClient: sts.Client()}
+		_, err := p.GetToken(context.Background(), execInfo(`{"clusterName": "spoke-1", "region": "us-west-2"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("AccessDenied: Not authorized")))
+	})
+
+	ginkgo.It("should fail without credentials", func() {
+		_, err := Provider{}.GetToken(context.Background(), execInfo(`{"clusterName": "spoke-1", "region": "us-west-2"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("no AWS credentials found")))
+	})
+
+	ginkgo.It("should reject region names that are not AWS regions", func() {
+		_, err := Provider{}.GetToken(context.Background(), execInfo(`{"clusterName": "spoke-1", "region": "evil.example.com/x"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("invalid region")))
+	})
+})
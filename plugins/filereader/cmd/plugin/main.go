@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/cluster-inventory-api/pkg/credentialplugin"
+)
+
+// ProviderName is the name of the credential provider.
+const ProviderName = "filereader"
+
+// Files in a cluster directory. A kubeconfig takes precedence over the others;
+// a token and a client certificate/key pair may be combined.
+const (
+	KubeconfigFile = "kubeconfig"
+	TokenFile      = "token"
+	ClientCertFile = "tls.crt"
+	ClientKeyFile  = "tls.key"
+)
+
+type Provider struct {
+	// Root is the directory holding one subdirectory per cluster.
+	Root string
+	// MaxStaleness, if set, rejects credentials whose files were last modified
+	// longer ago, and caps the expiry at the time they become stale.
+	MaxStaleness time.Duration
+	// Kubeconfig controls how credentials are extracted from a kubeconfig.
+	// Kubeconfig.Context is overridden by the context in ExecCredential.Spec.Cluster.Config.
+	Kubeconfig credentialplugin.KubeconfigOptions
+	// Log receives the staleness report of every call. Defaults to os.Stderr.
+	Log io.Writer
+}
+
+type execClusterConfig struct {
+	ClusterName string `json:"clusterName"` // Directory below the root (required)
+	Context     string `json:"context"`     // Optional: kubeconfig context name
+}
+
+func (Provider) Name() string { return ProviderName }
+
+func (p Provider) GetToken(
+	ctx context.Context,
+	info clientauthenticationv1.ExecCredential,
+) (clientauthenticationv1.ExecCredentialStatus, error) {
+	if p.Root == "" {
+		return clientauthenticationv1.ExecCredentialStatus{}, errors.New("credential root is not configured; set --root")
+	}
+
+	// Validate presence of cluster config
+	if info.Spec.Cluster == nil || len(info.Spec.Cluster.Config.Raw) == 0 {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("missing ExecCredential.Spec.Cluster.Config")
+	}
+	var cfg execClusterConfig
+	if err := json.Unmarshal(info.Spec.Cluster.Config.Raw, &cfg); err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{},
+			fmt.Errorf("invalid ExecCredential.Spec.Cluster.Config: %w", err)
+	}
+	if cfg.ClusterName == "" || cfg.ClusterName == "." || cfg.ClusterName == ".." ||
+		strings.ContainsAny(cfg.ClusterName, `/\`) {
+		return clientauthenticationv1.ExecCredentialStatus{},
+			fmt.Errorf("invalid clusterName %q in ExecCredential.Spec.Cluster.Config", cfg.ClusterName)
+	}
+
+	files := &clusterFiles{root: p.Root, dir: cfg.ClusterName}
+	var status clientauthenticationv1.ExecCredentialStatus
+
+	kubeconfig, err := files.read(KubeconfigFile)
+	switch {
+	case err == nil:
+		config, err := clientcmd.Load(kubeconfig)
+		if err != nil {
+			return clientauthenticationv1.ExecCredentialStatus{},
+				fmt.Errorf("failed to parse kubeconfig of cluster %q: %w", cfg.ClusterName, err)
+		}
+		opts := p.Kubeconfig
+		opts.Context = cfg.Context
+		status, err = credentialplugin.KubeconfigCredentials(ctx, config, info.Spec.Cluster, opts)
+		if err != nil {
+			return clientauthenticationv1.ExecCredentialStatus{}, err
+		}
+	case errors.Is(err, fs.ErrNotExist):
+		status, err = files.userCredentials()
+		if err != nil {
+			return clientauthenticationv1.ExecCredentialStatus{}, err
+		}
+	default:
+		return clientauthenticationv1.ExecCredentialStatus{}, err
+	}
+
+	// Report how old the credentials are, and refuse or expire stale ones
+	age := time.Since(files.modTime)
+	p.logf("credentials of cluster %q were last modified at %s (%s ago)",
+		cfg.ClusterName, files.modTime.UTC().Format(time.RFC3339), age.Round(time.Second))
+	if p.MaxStaleness > 0 {
+		if age > p.MaxStaleness {
+			return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
+				"credentials of cluster %q are stale: last modified %s ago, more than %s",
+				cfg.ClusterName, age.Round(time.Second), p.MaxStaleness,
+			)
+		}
+		staleAt := files.modTime.Add(p.MaxStaleness)
+		if status.ExpirationTimestamp == nil || staleAt.Before(status.ExpirationTimestamp.Time) {
+			status.ExpirationTimestamp = credentialplugin.ExpirationTimestamp(staleAt, 0)
+		}
+	}
+	return status, nil
+}
+
+func (p Provider) logf(format string, a ...any) {
+	w := p.Log
+	if w == nil {
+		w = os.Stderr
+	}
+	fmt.Fprintf(w, "["+ProviderName+"] "+format+"\n", a...)
+}
+
+// clusterFiles reads the files of one cluster directory and tracks the most
+// recent modification time among them.
+type clusterFiles struct {
+	root    string
+	dir     string
+	modTime time.Time
+}
+
+// read returns the content of name in the cluster directory. Symlinks, as
+// created by the Secrets Store CSI driver, must resolve inside the root.
+func (f *clusterFiles) read(name string) ([]byte, error) {
+	path := filepath.Join(f.root, f.dir, name)
+	fi, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	data, err := credentialplugin.ReadFileInRoot(f.root, path)
+	if err != nil {
+		return nil, err
+	}
+	if fi.ModTime().After(f.modTime) {
+		f.modTime = fi.ModTime()
+	}
+	return data, nil
+}
+
+// readOptional is read, returning nil if the file does not exist.
+func (f *clusterFiles) readOptional(name string) ([]byte, error) {
+	data, err := f.read(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// userCredentials reads a token and/or a client certificate/key pair.
+func (f *clusterFiles) userCredentials() (clientauthenticationv1.ExecCredentialStatus, error) {
+	token, err := f.readOptional(TokenFile)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, err
+	}
+	cert, err := f.readOptional(ClientCertFile)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, err
+	}
+	key, err := f.readOptional(ClientKeyFile)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, err
+	}
+
+	status := clientauthenticationv1.ExecCredentialStatus{Token: strings.TrimSpace(string(token))}
+	if (len(cert) > 0) != (len(key) > 0) {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
+			"cluster %q must have both %s and %s", f.dir, ClientCertFile, ClientKeyFile,
+		)
+	}
+	status.ClientCertificateData = string(cert)
+	status.ClientKeyData = string(key)
+
+	if status.Token == "" && status.ClientCertificateData == "" {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
+			"no credentials found for cluster %q: expected %s, %s or %s and %s in %s",
+			f.dir, KubeconfigFile, TokenFile, ClientCertFile, ClientKeyFile, filepath.Join(f.root, f.dir),
+		)
+	}
+	if status.Token != "" {
+		if exp, err := credentialplugin.JWTExpiry(status.Token); err == nil {
+			status.ExpirationTimestamp = credentialplugin.ExpirationTimestamp(exp, credentialplugin.DefaultExpirationSkew)
+		}
+	}
+	return status, nil
+}
+
+func main() {
+	root := flag.String("root", "", "Directory holding one subdirectory of credential files per cluster (required).")
+	maxStaleness := flag.Duration("max-staleness", 0,
+		"Reject credentials whose files were last modified longer ago. If unset, staleness is only reported.")
+	var kubeconfigOpts credentialplugin.KubeconfigOptions
+	kubeconfigOpts.AddFlags(flag.CommandLine)
+	flag.Parse()
+
+	credentialplugin.Run(Provider{
+		Root:         *root,
+		MaxStaleness: *maxStaleness,
+		Kubeconfig:   kubeconfigOpts,
+	})
+}
@@ -0,0 +1,173 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+)
+
+func TestFileReader(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "File Reader Plugin Suite")
+}
+
+func execInfo(config string) clientauthenticationv1.ExecCredential {
+	return clientauthenticationv1.ExecCredential{
+		Spec: clientauthenticationv1.ExecCredentialSpec{
+			Cluster: &clientauthenticationv1.Cluster{
+				Server: "https://spoke.example.com",
+				Config: runtime.RawExtension{Raw: []byte(config)},
+			},
+		},
+	}
+}
+
+var _ = ginkgo.Describe("Provider", func() {
+	var (
+		root string
+		log  *bytes.Buffer
+		p    Provider
+	)
+
+	writeFile := func(name, content string, modTime time.Time) {
+		path := filepath.Join(root, "spoke-1", name)
+		gomega.Expect(os.MkdirAll(filepath.Dir(path), 0o700)).To(gomega.Succeed())
+		gomega.Expect(os.WriteFile(path, []byte(content), 0o600)).To(gomega.Succeed())
+		gomega.Expect(os.Chtimes(path, modTime, modTime)).To(gomega.Succeed())
+	}
+
+	ginkgo.BeforeEach(func() {
+		root = ginkgo.GinkgoT().TempDir()
+		log = &bytes.Buffer{}
+		p = Provider{Root: root, Log: log}
+	})
+
+	ginkgo.It("should read a token and report its age", func() {
+		modTime := time.Now().Add(-time.Hour)
+		writeFile(TokenFile, "spoke-token\n", modTime)
+
+		status, err := p.GetToken(context.Background(), execInfo(`{"clusterName": "spoke-1"}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(status.Token).To(gomega.Equal("spoke-token"))
+		gomega.Expect(status.ExpirationTimestamp).To(gomega.BeNil())
+		gomega.Expect(log.String()).To(gomega.ContainSubstring(`cluster "spoke-1" were last modified at ` +
+			modTime.UTC().Format(time.RFC3339) + " (1h0m0s ago)"))
+	})
+
+	ginkgo.It("should read a client certificate and key", func() {
+		writeFile(ClientCertFile, "CERT", time.Now())
+		writeFile(ClientKeyFile, "KEY", time.Now())
+
+		status, err := p.GetToken(context.Background(), execInfo(`{"clusterName": "spoke-1"}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(status.ClientCertificateData).To(gomega.Equal("CERT"))
+		gomega.Expect(status.ClientKeyData).To(gomega.Equal("KEY"))
+	})
+
+	ginkgo.It("should reject a certificate without a key", func() {
+		writeFile(ClientCertFile, "CERT", time.Now())
+
+		_, err := p.GetToken(context.Background(), execInfo(`{"clusterName": "spoke-1"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("must have both")))
+	})
+
+	ginkgo.It("should prefer a kubeconfig and apply the kubeconfig rules", func() {
+		writeFile(TokenFile, "spoke-token", time.Now())
+		writeFile(KubeconfigFile, `apiVersion: v1
+kind: Config
+clusters:
+- name: spoke
+  cluster:
+    server: https://spoke.example.com
+contexts:
+- name: spoke
+  context:
+    cluster: spoke
+    user: admin
+current-context: spoke
+users:
+- name: admin
+  user:
+    client-certificate: ../other/tls.crt
+    client-key: ../other/tls.key
+`, time.Now())
+
+		_, err := p.GetToken(context.Background(), execInfo(`{"clusterName": "spoke-1"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("not supported")))
+	})
+
+	ginkgo.It("should re-read the files on every call", func() {
+		writeFile(TokenFile, "first", time.Now())
+		status, err := p.GetToken(context.Background(), execInfo(`{"clusterName": "spoke-1"}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(status.Token).To(gomega.Equal("first"))
+
+		writeFile(TokenFile, "second", time.Now())
+		status, err = p.GetToken(context.Background(), execInfo(`{"clusterName": "spoke-1"}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(status.Token).To(gomega.Equal("second"))
+	})
+
+	ginkgo.It("should expire credentials when they become stale", func() {
+		modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+		writeFile(TokenFile, "spoke-token", modTime)
+		p.MaxStaleness = 2 * time.Hour
+
+		status, err := p.GetToken(context.Background(), execInfo(`{"clusterName": "spoke-1"}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(status.ExpirationTimestamp).NotTo(gomega.BeNil())
+		gomega.Expect(status.ExpirationTimestamp.Time).To(gomega.BeTemporally("==", modTime.Add(2*time.Hour)))
+	})
+
+	ginkgo.It("should reject stale credentials", func() {
+		writeFile(TokenFile, "spoke-token", time.Now().Add(-3*time.Hour))
+		p.MaxStaleness = 2 * time.Hour
+
+		_, err := p.GetToken(context.Background(), execInfo(`{"clusterName": "spoke-1"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("are stale")))
+	})
+
+	ginkgo.It("should reject cluster names that leave the root", func() {
+		_, err := p.GetToken(context.Background(), execInfo(`{"clusterName": ".."}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("invalid clusterName")))
+	})
+
+	ginkgo.It("should reject symlinks that leave the root", func() {
+		outside := filepath.Join(ginkgo.GinkgoT().TempDir(), "token")
+		gomega.Expect(os.WriteFile(outside, []byte("secret"), 0o600)).To(gomega.Succeed())
+		gomega.Expect(os.MkdirAll(filepath.Join(root, "spoke-1"), 0o700)).To(gomega.Succeed())
+		gomega.Expect(os.Symlink(outside, filepath.Join(root, "spoke-1", TokenFile))).To(gomega.Succeed())
+
+		_, err := p.GetToken(context.Background(), execInfo(`{"clusterName": "spoke-1"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("outside of credential file root")))
+	})
+
+	ginkgo.It("should report missing credentials", func() {
+		_, err := p.GetToken(context.Background(), execInfo(`{"clusterName": "spoke-2"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("no credentials found")))
+	})
+})
@@ -0,0 +1,233 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+	"sigs.k8s.io/cluster-inventory-api/pkg/credentialplugin"
+)
+
+func TestKubeconfigSecretReader(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "Kubeconfig Secret Reader Plugin Suite")
+}
+
+const kubeconfigTemplate = `apiVersion: v1
+kind: Config
+clusters:
+- name: spoke
+  cluster:
+    server: https://spoke.example.com
+contexts:
+- name: spoke
+  context:
+    cluster: spoke
+    user: admin
+current-context: spoke
+users:
+- name: admin
+  user:
+`
+
+func newProvider(kubeconfig string) Provider {
+	return Provider{
+		KubeClient: fake.NewClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "spoke-kubeconfig", Namespace: "default"},
+			Data:       map[string][]byte{"value": []byte(kubeconfig)},
+		}),
+		Namespace: "default",
+	}
+}
+
+func execInfo() clientauthenticationv1.ExecCredential {
+	return clientauthenticationv1.ExecCredential{
+		Spec: clientauthenticationv1.ExecCredentialSpec{
+			Cluster: &clientauthenticationv1.Cluster{
+				Server: "https://spoke.example.com",
+				Config: runtime.RawExtension{Raw: []byte(`{"name":"spoke-kubeconfig","key":"value"}`)},
+			},
+		},
+	}
+}
+
+var _ = ginkgo.Describe("Provider", func() {
+	var tempDir string
+
+	ginkgo.BeforeEach(func() {
+		tempDir = ginkgo.GinkgoT().TempDir()
+	})
+
+	ginkgo.Describe("exec users", func() {
+		var script string
+
+		ginkgo.BeforeEach(func() {
+			script = filepath.Join(tempDir, "get-token")
+			gomega.Expect(os.WriteFile(script, []byte(`#!/bin/sh
+echo '{"apiVersion":"client.authentication.k8s.io/v1","kind":"ExecCredential","status":{"token":"'"$1"'"}}'
+`), 0o755)).To(gomega.Succeed())
+		})
+
+		kubeconfig := func() string {
+			return kubeconfigTemplate + `    exec:
+      apiVersion: client.authentication.k8s.io/v1
+      command: ` + script + `
+      args: ["nested-token"]
+`
+		}
+
+		ginkgo.It("should reject exec users when no command is allowed", func() {
+			_, err := newProvider(kubeconfig()).GetToken(context.Background(), execInfo())
+			gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("not enabled")))
+		})
+
+		ginkgo.It("should reject commands outside of the allowlist", func() {
+			p := newProvider(kubeconfig())
+			p.Kubeconfig.AllowedExecCommands = []string{"/usr/bin/true"}
+			_, err := p.GetToken(context.Background(), execInfo())
+			gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("is not allowed")))
+		})
+
+		ginkgo.It("should return the credentials of an allowed command", func() {
+			p := newProvider(kubeconfig())
+			p.Kubeconfig.AllowedExecCommands = []string{script}
+			status, err := p.GetToken(context.Background(), execInfo())
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(status.Token).To(gomega.Equal("nested-token"))
+		})
+	})
+
+	ginkgo.Describe("file-based certificates", func() {
+		kubeconfig := func(cert, key string) string {
+			return kubeconfigTemplate + `    client-certificate: ` + cert + `
+    client-key: ` + key + `
+`
+		}
+
+		ginkgo.BeforeEach(func() {
+			gomega.Expect(os.WriteFile(filepath.Join(tempDir, "tls.crt"), []byte("CERT"), 0o600)).To(gomega.Succeed())
+			gomega.Expect(os.WriteFile(filepath.Join(tempDir, "tls.key"), []byte("KEY"), 0o600)).To(gomega.Succeed())
+		})
+
+		ginkgo.It("should reject file paths when no root is configured", func() {
+			_, err := newProvider(kubeconfig("tls.crt", "tls.key")).GetToken(context.Background(), execInfo())
+			gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("not supported")))
+		})
+
+		ginkgo.It("should read file paths relative to the root", func() {
+			p := newProvider(kubeconfig("tls.crt", filepath.Join(tempDir, "tls.key")))
+			p.Kubeconfig.CredentialFileRoot = tempDir
+			status, err := p.GetToken(context.Background(), execInfo())
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(status.ClientCertificateData).To(gomega.Equal("CERT"))
+			gomega.Expect(status.ClientKeyData).To(gomega.Equal("KEY"))
+		})
+
+		ginkgo.It("should reject file paths that escape the root", func() {
+			root := filepath.Join(tempDir, "mount")
+			gomega.Expect(os.Mkdir(root, 0o700)).To(gomega.Succeed())
+			p := newProvider(kubeconfig("../tls.crt", "../tls.key"))
+			p.Kubeconfig.CredentialFileRoot = root
+			_, err := p.GetToken(context.Background(), execInfo())
+			gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("outside of credential file root")))
+		})
+	})
+
+	ginkgo.Describe("encrypted kubeconfigs", func() {
+		var sealed string
+
+		ginkgo.BeforeEach(func() {
+			publicKey, privateKey, err := credentialplugin.GenerateEnvelopeKey()
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(os.WriteFile(filepath.Join(tempDir, "hub"), []byte(privateKey), 0o600)).To(gomega.Succeed())
+			data, err := credentialplugin.SealEnvelope([]byte(kubeconfigTemplate+"    token: sealed-token\n"), publicKey)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			sealed = string(data)
+		})
+
+		ginkgo.It("should decrypt the kubeconfig with the mounted key", func() {
+			p := newProvider(sealed)
+			p.Envelope.KeyDir = tempDir
+			status, err := p.GetToken(context.Background(), execInfo())
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(status.Token).To(gomega.Equal("sealed-token"))
+		})
+
+		ginkgo.It("should reject an encrypted kubeconfig without keys", func() {
+			_, err := newProvider(sealed).GetToken(context.Background(), execInfo())
+			gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("no decryption key directory")))
+		})
+
+		ginkgo.It("should reject a plaintext kubeconfig when encryption is required", func() {
+			p := newProvider(kubeconfigTemplate + "    token: plain-token\n")
+			p.Envelope.RequireEncryption = true
+			_, err := p.GetToken(context.Background(), execInfo())
+			gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("not encrypted")))
+		})
+	})
+
+	ginkgo.It("should return the current kubeconfig version and expire with it", func() {
+		notAfter := time.Now().Add(time.Hour).Truncate(time.Second)
+		p := Provider{
+			KubeClient: fake.NewClientset(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "spoke-kubeconfig", Namespace: "default"},
+				Data: map[string][]byte{
+					"value.1":           []byte(kubeconfigTemplate + "    token: old-token\n"),
+					"value.2":           []byte(kubeconfigTemplate + "    token: new-token\n"),
+					"value.2.notBefore": []byte(time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)),
+					"value.2.notAfter":  []byte(notAfter.UTC().Format(time.RFC3339)),
+				},
+			}),
+			Namespace: "default",
+		}
+		status, err := p.GetToken(context.Background(), execInfo())
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(status.Token).To(gomega.Equal("new-token"))
+		gomega.Expect(status.ExpirationTimestamp).NotTo(gomega.BeNil())
+		gomega.Expect(status.ExpirationTimestamp.Time).To(gomega.BeTemporally("==", notAfter.Add(-30*time.Second)))
+	})
+
+	ginkgo.It("should only read Secrets in the allowed namespaces", func() {
+		p := newProvider(kubeconfigTemplate + "    token: plain-token\n")
+		info := execInfo()
+		info.Spec.Cluster.Config.Raw = []byte(`{"name":"spoke-kubeconfig","key":"value","namespace":"kube-system"}`)
+		_, err := p.GetToken(context.Background(), info)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`namespace "kube-system" is not allowed`)))
+
+		p.Namespaces.AllowedNamespaces = []string{"kube-system"}
+		_, err = p.GetToken(context.Background(), execInfo())
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`namespace "default" is not allowed`)))
+	})
+
+	ginkgo.It("should reject auth-provider users", func() {
+		_, err := newProvider(kubeconfigTemplate+`    auth-provider:
+      name: oidc
+`).GetToken(context.Background(), execInfo())
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("auth-provider")))
+	})
+})
@@ -4,14 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
 	"os"
-	"strings"
+	"time"
 
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubernetes "k8s.io/client-go/kubernetes"
 	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
-	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/cluster-inventory-api/pkg/credentialplugin"
 )
@@ -19,28 +18,27 @@ import (
 type Provider struct {
 	// KubeClient is the typed client for core Kubernetes resources (e.g. Secret).
 	KubeClient kubernetes.Interface
+	// Secrets, if set, is used to read Secrets instead of live requests through KubeClient.
+	Secrets credentialplugin.SecretReader
 	// Namespace, if set, overrides namespace inference.
 	Namespace string
+	// Namespaces restricts the namespaces a ClusterProfile may read Secrets from.
+	Namespaces credentialplugin.NamespaceOptions
+
+	// Kubeconfig controls how credentials are extracted from the kubeconfig.
+	// Kubeconfig.Context is overridden by the context in ExecCredential.Spec.Cluster.Config.
+	Kubeconfig credentialplugin.KubeconfigOptions
+	// Envelope controls decryption of an encrypted kubeconfig.
+	Envelope credentialplugin.EnvelopeOptions
 }
 
 // NewDefault constructs a Provider with pre-initialized typed clientsets and an inferred namespace.
 func NewDefault() (*Provider, error) {
-	// Build Kubernetes rest.Config via in-cluster first, then fallback to kubeconfig
-	cfg, err := rest.InClusterConfig()
+	kubeClient, err := credentialplugin.NewKubeClient()
 	if err != nil {
-		kubeconfig := os.Getenv("KUBECONFIG")
-		cfg, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
-		if err != nil {
-			return nil, fmt.Errorf("failed to build kube client config: %w", err)
-		}
+		return nil, err
 	}
-
-	kubeClient, err := kubernetes.NewForConfig(cfg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create kubernetes clientset: %w", err)
-	}
-
-	return &Provider{KubeClient: kubeClient, Namespace: inferNamespace()}, nil
+	return &Provider{KubeClient: kubeClient, Namespace: credentialplugin.InferNamespace()}, nil
 }
 
 // ProviderName is the name of the credential provider.
@@ -55,12 +53,17 @@ type execClusterConfig struct {
 
 func (Provider) Name() string { return ProviderName }
 
+// secrets returns the SecretReader used to read Secrets.
+func (p Provider) secrets() credentialplugin.SecretReader {
+	return credentialplugin.SecretReaderOrClient(p.Secrets, p.KubeClient)
+}
+
 func (p Provider) GetToken(
 	ctx context.Context,
 	info clientauthenticationv1.ExecCredential,
 ) (clientauthenticationv1.ExecCredentialStatus, error) {
 	// Require pre-initialized typed clients
-	if p.KubeClient == nil {
+	if p.KubeClient == nil && p.Secrets == nil {
 		return clientauthenticationv1.ExecCredentialStatus{}, errors.New(
 			"provider clients are not initialized; construct with NewDefault or set clients",
 		)
@@ -89,9 +92,12 @@ func (p Provider) GetToken(
 	if namespace == "" {
 		namespace = p.Namespace
 	}
+	if err := p.Namespaces.Check(namespace, p.Namespace); err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, err
+	}
 
 	// Read Secret
-	sec, err := p.KubeClient.CoreV1().Secrets(namespace).Get(ctx, cfg.Name, metav1.GetOptions{})
+	sec, err := p.secrets().GetSecret(ctx, namespace, cfg.Name)
 	if err != nil {
 		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
 			"failed to get secret %s/%s: %w",
@@ -100,136 +106,104 @@ func (p Provider) GetToken(
 			err,
 		)
 	}
-	kubeconfigData, ok := sec.Data[cfg.Key]
-	if !ok || len(kubeconfigData) == 0 {
+	// Secrets in the rotation layout hold several versions of the kubeconfig
+	kubeconfigData, notAfter, err := credentialplugin.SecretCredential(sec, cfg.Key, time.Now())
+	if err != nil {
 		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
-			"secret %s/%s missing %q key",
+			"secret %s/%s: %w",
 			namespace,
 			cfg.Name,
-			cfg.Key,
+			err,
 		)
 	}
 
-	// Parse kubeconfig
-	config, err := clientcmd.Load(kubeconfigData)
+	kubeconfigData, err = p.Envelope.Open(kubeconfigData)
 	if err != nil {
 		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
-			"failed to parse kubeconfig from secret %s/%s: %w",
+			"secret %s/%s key %q: %w",
 			namespace,
 			cfg.Name,
+			cfg.Key,
 			err,
 		)
 	}
 
-	// Check for unsupported extensions
-	if len(config.Extensions) > 0 {
-		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("kubeconfig extensions are not supported")
-	}
-
-	// Determine context: use provided context, or fallback to current-context
-	contextName := cfg.Context
-	if contextName == "" {
-		contextName = config.CurrentContext
-	}
-	if contextName == "" {
+	// Parse kubeconfig
+	config, err := clientcmd.Load(kubeconfigData)
+	if err != nil {
 		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
-			"no context specified and no current-context in kubeconfig",
+			"failed to parse kubeconfig from secret %s/%s: %w",
+			namespace,
+			cfg.Name,
+			err,
 		)
 	}
 
-	// Get context
-	context, ok := config.Contexts[contextName]
-	if !ok {
-		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("context %q not found in kubeconfig", contextName)
+	opts := p.Kubeconfig
+	opts.Context = cfg.Context
+	status, err := credentialplugin.KubeconfigCredentials(ctx, config, info.Spec.Cluster, opts)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, err
 	}
-
-	// Get user
-	user, ok := config.AuthInfos[context.AuthInfo]
-	if !ok {
-		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("user %q not found in kubeconfig", context.AuthInfo)
+	// Refresh no later than the kubeconfig version expires
+	if exp := credentialplugin.ExpirationTimestamp(notAfter, credentialplugin.DefaultExpirationSkew); exp != nil &&
+		(status.ExpirationTimestamp == nil || exp.Before(status.ExpirationTimestamp)) {
+		status.ExpirationTimestamp = exp
 	}
+	return status, nil
+}
 
-	// Build ExecCredentialStatus - support both token and client certificate/key
-	status := clientauthenticationv1.ExecCredentialStatus{}
-
-	// Handle token authentication
-	if user.Token != "" {
-		status.Token = user.Token
+func main() {
+	var kubeconfigOpts credentialplugin.KubeconfigOptions
+	kubeconfigOpts.AddFlags(flag.CommandLine)
+	var envelopeOpts credentialplugin.EnvelopeOptions
+	envelopeOpts.AddFlags(flag.CommandLine)
+	daemon := flag.Bool("daemon", false,
+		"Run as a long-lived daemon that serves requests on --socket from a Secret informer cache.")
+	socket := flag.String("socket", "",
+		"Unix socket of the credential daemon. Without --daemon, requests are forwarded to the daemon "+
+			"and served directly if it is not running.")
+	var namespaceOpts credentialplugin.NamespaceOptions
+	namespaceOpts.AddFlags(flag.CommandLine)
+	flag.Parse()
+
+	newProvider := func() (*Provider, error) {
+		p, err := NewDefault()
+		if err != nil {
+			return nil, err
+		}
+		p.Kubeconfig = kubeconfigOpts
+		p.Envelope = envelopeOpts
+		p.Namespaces = namespaceOpts
+		return p, nil
 	}
 
-	// Handle client certificate/key authentication
-	hasClientCert := len(user.ClientCertificateData) > 0 || user.ClientCertificate != ""
-	hasClientKey := len(user.ClientKeyData) > 0 || user.ClientKey != ""
-
-	if hasClientCert || hasClientKey {
-		// Both certificate and key must be present
-		if !hasClientCert {
-			return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
-				"client-key-data found but no client-certificate-data in user %q",
-				context.AuthInfo,
-			)
+	switch {
+	case *daemon:
+		if *socket == "" {
+			fmt.Fprintln(os.Stderr, "["+ProviderName+"] --daemon requires --socket")
+			os.Exit(1)
 		}
-		if !hasClientKey {
-			return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
-				"client-certificate-data found but no client-key-data in user %q",
-				context.AuthInfo,
-			)
-		}
-
-		// Handle client-certificate-data
-		if len(user.ClientCertificateData) > 0 {
-			// Already decoded (PEM string)
-			status.ClientCertificateData = string(user.ClientCertificateData)
-		} else if user.ClientCertificate != "" {
-			// File path - not supported in this plugin
-			return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
-				"client-certificate file path is not supported; use client-certificate-data",
-			)
+		p, err := newProvider()
+		if err != nil {
+			panic(err)
 		}
-
-		// Handle client-key-data
-		if len(user.ClientKeyData) > 0 {
-			// Already decoded (PEM string)
-			status.ClientKeyData = string(user.ClientKeyData)
-		} else if user.ClientKey != "" {
-			// File path - not supported in this plugin
-			return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
-				"client-key file path is not supported; use client-key-data",
-			)
+		p.Secrets = credentialplugin.NewCachedSecretReader(context.Background(), p.KubeClient, 0,
+			p.Namespaces.Namespaces(p.Namespace))
+		credentialplugin.RunDaemon(*p, *socket)
+	case *socket != "":
+		credentialplugin.RunShim(ProviderName, *socket, func() (credentialplugin.Provider, error) {
+			p, err := newProvider()
+			if err != nil {
+				return nil, err
+			}
+			return *p, nil
+		})
+	default:
+		p, err := newProvider()
+		if err != nil {
+			panic(err)
 		}
+		credentialplugin.Run(*p)
 	}
-
-	// At least one authentication method must be present
-	if status.Token == "" && status.ClientCertificateData == "" {
-		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
-			"no authentication method found in user %q "+
-				"(neither token nor client-certificate-data/client-key-data)",
-			context.AuthInfo,
-		)
-	}
-
-	return status, nil
-}
-
-// inferNamespace returns the namespace to read Secrets from, preferring the
-// kubeconfig current-context namespace and falling back to the namespace of
-// the Pod this process runs in.
-func inferNamespace() string {
-	rules := clientcmd.NewDefaultClientConfigLoadingRules()
-	if path := os.Getenv("KUBECONFIG"); strings.TrimSpace(path) != "" {
-		rules.ExplicitPath = path
-	}
-	cc := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{})
-	if n, _, err := cc.Namespace(); err == nil && strings.TrimSpace(n) != "" {
-		return n
-	}
-	return "default"
-}
-
-func main() {
-	p, err := NewDefault()
-	if err != nil {
-		panic(err)
-	}
-	credentialplugin.Run(*p)
 }
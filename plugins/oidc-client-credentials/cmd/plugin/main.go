@@ -0,0 +1,329 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	kubernetes "k8s.io/client-go/kubernetes"
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+	"sigs.k8s.io/cluster-inventory-api/pkg/credentialplugin"
+)
+
+type Provider struct {
+	// KubeClient is the typed client for core Kubernetes resources (e.g. Secret).
+	// It is only required for configs that use clientSecretRef.
+	KubeClient kubernetes.Interface
+	// Secrets, if set, is used to read Secrets instead of live requests through KubeClient.
+	Secrets credentialplugin.SecretReader
+	// Namespace, if set, overrides namespace inference.
+	Namespace string
+	// Namespaces restricts the namespaces a ClusterProfile may read the client secret from.
+	Namespaces credentialplugin.NamespaceOptions
+	// HTTPClient is used for discovery and token requests. Defaults to a client with credentialplugin.HTTPTimeout.
+	HTTPClient *http.Client
+	// ClientSecretFileRoot, if set, enables clientSecretFile. Relative paths are
+	// resolved against this directory and absolute paths must point inside it.
+	ClientSecretFileRoot string
+	// AllowedTokenURLs lists token endpoints the client secret may be sent to.
+	AllowedTokenURLs []string
+	// AllowedIssuers lists issuers whose discovery document may be fetched.
+	// Token endpoints on the same host as an allowed issuer are allowed too.
+	// If both lists are empty, every request is refused.
+	AllowedIssuers []string
+}
+
+// NewDefault constructs a Provider with an inferred namespace and, if a kube
+// client config can be built, pre-initialized typed clientsets.
+func NewDefault() (*Provider, error) {
+	p := &Provider{Namespace: credentialplugin.InferNamespace()}
+
+	// The client is only needed for clientSecretRef, so the absence of a
+	// cluster config is not an error here.
+	cfg, err := credentialplugin.KubeClientConfig()
+	if err != nil {
+		return p, nil
+	}
+	kubeClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes clientset: %w", err)
+	}
+	p.KubeClient = kubeClient
+	return p, nil
+}
+
+// ProviderName is the name of the credential provider.
+const ProviderName = "oidc-client-credentials"
+
+// secretRef references a key of a Secret holding the client secret.
+type secretRef struct {
+	Name      string `json:"name"`      // Secret name (required)
+	Namespace string `json:"namespace"` // Optional: namespace to read Secret from
+	Key       string `json:"key"`       // Optional: Secret.data key (defaults to clientSecret)
+}
+
+type execClusterConfig struct {
+	Issuer           string     `json:"issuer"`           // OIDC issuer URL, used to discover the token endpoint
+	TokenURL         string     `json:"tokenURL"`         // Token endpoint URL; takes precedence over issuer
+	ClientID         string     `json:"clientID"`         // OAuth2 client ID (required)
+	ClientSecretRef  *secretRef `json:"clientSecretRef"`  // Secret holding the client secret
+	ClientSecretFile string     `json:"clientSecretFile"` // File holding the client secret, under --client-secret-root
+	Audience         string     `json:"audience"`         // Optional: audience parameter of the token request
+	Scopes           []string   `json:"scopes"`           // Optional: requested scopes
+	AuthStyle        string     `json:"authStyle"`        // Optional: "header" (default) or "params"
+}
+
+// defaultClientSecretKey is the default `Secret.data` key of clientSecretRef.
+const defaultClientSecretKey = "clientSecret"
+
+func (Provider) Name() string { return ProviderName }
+
+// secrets returns the SecretReader used to read Secrets.
+func (p Provider) secrets() credentialplugin.SecretReader {
+	return credentialplugin.SecretReaderOrClient(p.Secrets, p.KubeClient)
+}
+
+func (p Provider) httpClient() *http.Client {
+	if p.HTTPClient != nil {
+		return p.HTTPClient
+	}
+	return credentialplugin.NewHTTPClient(nil)
+}
+
+func (p Provider) GetToken(
+	ctx context.Context,
+	info clientauthenticationv1.ExecCredential,
+) (clientauthenticationv1.ExecCredentialStatus, error) {
+	// Validate presence of cluster config
+	if info.Spec.Cluster == nil || len(info.Spec.Cluster.Config.Raw) == 0 {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("missing ExecCredential.Spec.Cluster.Config")
+	}
+	var cfg execClusterConfig
+	if err := json.Unmarshal(info.Spec.Cluster.Config.Raw, &cfg); err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{},
+			fmt.Errorf("invalid ExecCredential.Spec.Cluster.Config: %w", err)
+	}
+	if cfg.ClientID == "" {
+		return clientauthenticationv1.ExecCredentialStatus{},
+			fmt.Errorf("missing clientID in ExecCredential.Spec.Cluster.Config")
+	}
+	var authStyle oauth2.AuthStyle
+	switch cfg.AuthStyle {
+	case "", "header":
+		authStyle = oauth2.AuthStyleInHeader
+	case "params":
+		authStyle = oauth2.AuthStyleInParams
+	default:
+		return clientauthenticationv1.ExecCredentialStatus{},
+			fmt.Errorf("unsupported authStyle %q in ExecCredential.Spec.Cluster.Config", cfg.AuthStyle)
+	}
+
+	if len(p.AllowedTokenURLs) == 0 && len(p.AllowedIssuers) == 0 {
+		return clientauthenticationv1.ExecCredentialStatus{},
+			errors.New("no token endpoint is allowed; set --allowed-token-url or --allowed-issuer")
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.httpClient())
+
+	tokenURL, err := p.tokenURL(ctx, cfg)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, err
+	}
+	clientSecret, err := p.clientSecret(ctx, cfg)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, err
+	}
+
+	cc := clientcredentials.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: clientSecret,
+		TokenURL:     tokenURL,
+		Scopes:       cfg.Scopes,
+		AuthStyle:    authStyle,
+	}
+	if cfg.Audience != "" {
+		cc.EndpointParams = url.Values{"audience": {cfg.Audience}}
+	}
+	tok, err := cc.Token(ctx)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("token request to %s failed: %w", tokenURL, err)
+	}
+
+	// Prefer expires_in from the token response, then the exp claim of a JWT access token
+	expiry := tok.Expiry
+	if expiry.IsZero() {
+		if exp, err := credentialplugin.JWTExpiry(tok.AccessToken); err == nil {
+			expiry = exp
+		}
+	}
+
+	return clientauthenticationv1.ExecCredentialStatus{
+		Token:               tok.AccessToken,
+		ExpirationTimestamp: credentialplugin.ExpirationTimestamp(expiry, credentialplugin.DefaultExpirationSkew),
+	}, nil
+}
+
+// tokenURL returns the configured token endpoint, or discovers it from the
+// issuer's OpenID configuration. Both must be allowed, since the client secret
+// is sent to the returned endpoint.
+func (p Provider) tokenURL(ctx context.Context, cfg execClusterConfig) (string, error) {
+	if cfg.TokenURL != "" {
+		if err := requireHTTPS(cfg.TokenURL); err != nil {
+			return "", fmt.Errorf("invalid tokenURL: %w", err)
+		}
+		if !p.tokenURLAllowed(cfg.TokenURL) {
+			return "", fmt.Errorf("tokenURL %q is not allowed", cfg.TokenURL)
+		}
+		return cfg.TokenURL, nil
+	}
+	if cfg.Issuer == "" {
+		return "", fmt.Errorf("missing issuer or tokenURL in ExecCredential.Spec.Cluster.Config")
+	}
+	if err := requireHTTPS(cfg.Issuer); err != nil {
+		return "", fmt.Errorf("invalid issuer: %w", err)
+	}
+	if !slices.Contains(p.AllowedIssuers, cfg.Issuer) {
+		return "", fmt.Errorf("issuer %q is not allowed", cfg.Issuer)
+	}
+
+	discoveryURL := strings.TrimSuffix(cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build discovery request: %w", err)
+	}
+	resp, err := p.httpClient().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch %s: %w", discoveryURL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch %s: unexpected status %s", discoveryURL, resp.Status)
+	}
+	var discovery struct {
+		Issuer        string `json:"issuer"`
+		TokenEndpoint string `json:"token_endpoint"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", discoveryURL, err)
+	}
+	// OpenID Connect Discovery 1.0, section 4.3: the issuer must match exactly
+	if discovery.Issuer != cfg.Issuer {
+		return "", fmt.Errorf("discovered issuer %q does not match configured issuer %q", discovery.Issuer, cfg.Issuer)
+	}
+	if err := requireHTTPS(discovery.TokenEndpoint); err != nil {
+		return "", fmt.Errorf("invalid token_endpoint in %s: %w", discoveryURL, err)
+	}
+	if !p.tokenURLAllowed(discovery.TokenEndpoint) {
+		return "", fmt.Errorf("token_endpoint %q in %s is not allowed", discovery.TokenEndpoint, discoveryURL)
+	}
+	return discovery.TokenEndpoint, nil
+}
+
+// tokenURLAllowed reports whether the client secret may be sent to the https
+// URL tokenURL: it is listed in AllowedTokenURLs, or served by the host of one
+// of the AllowedIssuers.
+func (p Provider) tokenURLAllowed(tokenURL string) bool {
+	if slices.Contains(p.AllowedTokenURLs, tokenURL) {
+		return true
+	}
+	u, err := url.Parse(tokenURL)
+	if err != nil {
+		return false
+	}
+	for _, issuer := range p.AllowedIssuers {
+		if iu, err := url.Parse(issuer); err == nil && iu.Scheme == u.Scheme && iu.Host == u.Host {
+			return true
+		}
+	}
+	return false
+}
+
+// clientSecret reads the client secret from the configured Secret or file.
+func (p Provider) clientSecret(ctx context.Context, cfg execClusterConfig) (string, error) {
+	switch {
+	case cfg.ClientSecretRef != nil && cfg.ClientSecretFile != "":
+		return "", fmt.Errorf("clientSecretRef and clientSecretFile are mutually exclusive")
+	case cfg.ClientSecretRef != nil:
+		ref := cfg.ClientSecretRef
+		if ref.Name == "" {
+			return "", fmt.Errorf("missing clientSecretRef.name in ExecCredential.Spec.Cluster.Config")
+		}
+		if p.KubeClient == nil && p.Secrets == nil {
+			return "", errors.New("provider clients are not initialized; construct with NewDefault or set clients")
+		}
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = p.Namespace
+		}
+		if err := p.Namespaces.Check(namespace, p.Namespace); err != nil {
+			return "", err
+		}
+		key := ref.Key
+		if key == "" {
+			key = defaultClientSecretKey
+		}
+		sec, err := p.secrets().GetSecret(ctx, namespace, ref.Name)
+		if err != nil {
+			return "", fmt.Errorf("failed to get secret %s/%s: %w", namespace, ref.Name, err)
+		}
+		data, ok := sec.Data[key]
+		if !ok || len(data) == 0 {
+			return "", fmt.Errorf("secret %s/%s missing %q key", namespace, ref.Name, key)
+		}
+		return strings.TrimSpace(string(data)), nil
+	case cfg.ClientSecretFile != "":
+		if p.ClientSecretFileRoot == "" {
+			return "", fmt.Errorf("clientSecretFile is not supported; set --client-secret-root")
+		}
+		data, err := credentialplugin.ReadFileInRoot(p.ClientSecretFileRoot, cfg.ClientSecretFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read clientSecretFile: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	default:
+		return "", fmt.Errorf("missing clientSecretRef or clientSecretFile in ExecCredential.Spec.Cluster.Config")
+	}
+}
+
+// requireHTTPS rejects URLs that would send client credentials in clear text.
+func requireHTTPS(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("%q is not an https URL", raw)
+	}
+	return nil
+}
+
+func main() {
+	clientSecretRoot := flag.String("client-secret-root", "",
+		"Directory against which clientSecretFile paths are resolved. If unset, clientSecretFile is rejected.")
+	var allowedTokenURLs, allowedIssuers credentialplugin.StringList
+	flag.Var(&allowedTokenURLs, "allowed-token-url",
+		"Token endpoint that the client secret may be sent to. Repeat to allow several.")
+	flag.Var(&allowedIssuers, "allowed-issuer",
+		"Issuer whose token endpoint is discovered and that the client secret may be sent to. Repeat to allow several.")
+	var namespaceOpts credentialplugin.NamespaceOptions
+	namespaceOpts.AddFlags(flag.CommandLine)
+	flag.Parse()
+
+	p, err := NewDefault()
+	if err != nil {
+		panic(err)
+	}
+	p.ClientSecretFileRoot = *clientSecretRoot
+	p.AllowedTokenURLs = allowedTokenURLs
+	p.AllowedIssuers = allowedIssuers
+	p.Namespaces = namespaceOpts
+	credentialplugin.Run(*p)
+}
@@ -0,0 +1,272 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+)
+
+func TestOIDCClientCredentials(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "OIDC Client Credentials Plugin Suite")
+}
+
+// fakeIdP is a minimal OpenID provider serving discovery and the token endpoint.
+type fakeIdP struct {
+	server *httptest.Server
+	// tokenEndpoint, if set, is advertised instead of the IdP's own endpoint.
+	tokenEndpoint string
+	requests      []http.Request
+	forms         []map[string][]string
+	discoveries   int
+}
+
+func newFakeIdP() *fakeIdP {
+	idp := &fakeIdP{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		idp.discoveries++
+		tokenEndpoint := idp.server.URL + "/token"
+		if idp.tokenEndpoint != "" {
+			tokenEndpoint = idp.tokenEndpoint
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":         idp.server.URL,
+			"token_endpoint": tokenEndpoint,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		idp.requests = append(idp.requests, *r)
+		idp.forms = append(idp.forms, r.PostForm)
+		if id, secret, ok := r.BasicAuth(); !ok || id != "client-a" || secret != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	})
+	idp.server = httptest.NewTLSServer(mux)
+	return idp
+}
+
+func execInfo(config string) clientauthenticationv1.ExecCredential {
+	return clientauthenticationv1.ExecCredential{
+		Spec: clientauthenticationv1.ExecCredentialSpec{
+			Cluster: &clientauthenticationv1.Cluster{
+				Server: "https://spoke.example.com",
+				Config: runtime.RawExtension{Raw: []byte(config)},
+			},
+		},
+	}
+}
+
+var _ = ginkgo.Describe("Provider", func() {
+	var (
+		idp    *fakeIdP
+		client *fake.Clientset
+		p      Provider
+	)
+
+	ginkgo.BeforeEach(func() {
+		idp = newFakeIdP()
+		ginkgo.DeferCleanup(idp.server.Close)
+		client = fake.NewClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "oidc-client", Namespace: "default"},
+			Data:       map[string][]byte{"clientSecret": []byte("s3cret\n")},
+		})
+		p = Provider{
+			KubeClient:     client,
+			Namespace:      "default",
+			HTTPClient:     idp.server.Client(),
+			AllowedIssuers: []string{idp.server.URL},
+		}
+	})
+
+	ginkgo.It("should discover the token endpoint and return the access token with its expiry", func() {
+		start := time.Now()
+		status, err := p.GetToken(context.Background(), execInfo(`{
+			"issuer": "`+idp.server.URL+`",
+			"clientID": "client-a",
+			"clientSecretRef": {"name": "oidc-client"},
+			"audience": "spoke-1",
+			"scopes": ["a", "b"]
+		}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(status.Token).To(gomega.Equal("access-token"))
+		gomega.Expect(status.ExpirationTimestamp).NotTo(gomega.BeNil())
+		gomega.Expect(status.ExpirationTimestamp.Time).To(gomega.BeTemporally("~", start.Add(time.Hour-30*time.Second), 5*time.Second))
+
+		gomega.Expect(idp.forms).To(gomega.HaveLen(1))
+		gomega.Expect(idp.forms[0]).To(gomega.HaveKeyWithValue("grant_type", []string{"client_credentials"}))
+		gomega.Expect(idp.forms[0]).To(gomega.HaveKeyWithValue("scope", []string{"a b"}))
+		gomega.Expect(idp.forms[0]).To(gomega.HaveKeyWithValue("audience", []string{"spoke-1"}))
+	})
+
+	ginkgo.It("should read the client secret from a file under the configured root", func() {
+		root := ginkgo.GinkgoT().TempDir()
+		gomega.Expect(os.WriteFile(filepath.Join(root, "secret"), []byte("s3cret"), 0o600)).To(gomega.Succeed())
+		p.ClientSecretFileRoot = root
+
+		status, err := p.GetToken(context.Background(), execInfo(`{
+			"tokenURL": "`+idp.server.URL+`/token",
+			"clientID": "client-a",
+			"clientSecretFile": "secret"
+		}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(status.Token).To(gomega.Equal("access-token"))
+	})
+
+	ginkgo.It("should reject clientSecretFile without a configured root", func() {
+		_, err := p.GetToken(context.Background(), execInfo(`{
+			"tokenURL": "`+idp.server.URL+`/token",
+			"clientID": "client-a",
+			"clientSecretFile": "/etc/passwd"
+		}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("--client-secret-root")))
+	})
+
+	ginkgo.It("should report token endpoint errors", func() {
+		_, err := p.GetToken(context.Background(), execInfo(`{
+			"tokenURL": "`+idp.server.URL+`/token",
+			"clientID": "client-b",
+			"clientSecretRef": {"name": "oidc-client"}
+		}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("invalid_client")))
+	})
+
+	ginkgo.It("should refuse to send credentials over plain http", func() {
+		_, err := p.GetToken(context.Background(), execInfo(`{
+			"tokenURL": "http://idp.example.com/token",
+			"clientID": "client-a",
+			"clientSecretRef": {"name": "oidc-client"}
+		}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("not an https URL")))
+		gomega.Expect(idp.requests).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should reject a discovery document for another issuer", func() {
+		p.AllowedIssuers = []string{idp.server.URL + "/"}
+		_, err := p.GetToken(context.Background(), execInfo(`{
+			"issuer": "`+idp.server.URL+`/",
+			"clientID": "client-a",
+			"clientSecretRef": {"name": "oidc-client"}
+		}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("does not match configured issuer")))
+	})
+
+	ginkgo.Describe("allowed endpoints", func() {
+		// expectNothingSent checks that no Secret was read and no credential was sent.
+		expectNothingSent := func() {
+			gomega.Expect(client.Actions()).To(gomega.BeEmpty())
+			gomega.Expect(idp.requests).To(gomega.BeEmpty())
+		}
+
+		ginkgo.It("should refuse every request when no endpoint is allowed", func() {
+			p.AllowedIssuers = nil
+			_, err := p.GetToken(context.Background(), execInfo(`{
+				"issuer": "`+idp.server.URL+`",
+				"clientID": "client-a",
+				"clientSecretRef": {"name": "oidc-client"}
+			}`))
+			gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("--allowed-token-url or --allowed-issuer")))
+			gomega.Expect(idp.discoveries).To(gomega.BeZero())
+			expectNothingSent()
+		})
+
+		ginkgo.It("should refuse a tokenURL that is not allowed", func() {
+			_, err := p.GetToken(context.Background(), execInfo(`{
+				"tokenURL": "https://attacker.example.com/token",
+				"clientID": "client-a",
+				"clientSecretRef": {"name": "oidc-client"}
+			}`))
+			gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`tokenURL "https://attacker.example.com/token" is not allowed`)))
+			expectNothingSent()
+		})
+
+		ginkgo.It("should refuse an issuer that is not allowed", func() {
+			p.AllowedIssuers = []string{"https://idp.example.com"}
+			_, err := p.GetToken(context.Background(), execInfo(`{
+				"issuer": "`+idp.server.URL+`",
+				"clientID": "client-a",
+				"clientSecretRef": {"name": "oidc-client"}
+			}`))
+			gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("is not allowed")))
+			gomega.Expect(idp.discoveries).To(gomega.BeZero())
+			expectNothingSent()
+		})
+
+		ginkgo.It("should refuse a discovered token_endpoint that is not allowed", func() {
+			idp.tokenEndpoint = "https://attacker.example.com/token"
+			_, err := p.GetToken(context.Background(), execInfo(`{
+				"issuer": "`+idp.server.URL+`",
+				"clientID": "client-a",
+				"clientSecretRef": {"name": "oidc-client"}
+			}`))
+			gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`token_endpoint "https://attacker.example.com/token"`)))
+			expectNothingSent()
+		})
+
+		ginkgo.It("should send the client secret to a token URL listed with --allowed-token-url", func() {
+			p.AllowedIssuers = nil
+			p.AllowedTokenURLs = []string{idp.server.URL + "/token"}
+			status, err := p.GetToken(context.Background(), execInfo(`{
+				"tokenURL": "`+idp.server.URL+`/token",
+				"clientID": "client-a",
+				"clientSecretRef": {"name": "oidc-client"}
+			}`))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(status.Token).To(gomega.Equal("access-token"))
+		})
+	})
+
+	ginkgo.It("should only read the client secret in the allowed namespaces", func() {
+		info := execInfo(`{
+			"issuer": "` + idp.server.URL + `",
+			"clientID": "client-a",
+			"clientSecretRef": {"name": "oidc-client", "namespace": "kube-system"}
+		}`)
+		_, err := p.GetToken(context.Background(), info)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`namespace "kube-system" is not allowed`)))
+		gomega.Expect(client.Actions()).To(gomega.BeEmpty())
+		gomega.Expect(idp.requests).To(gomega.BeEmpty())
+
+		p.Namespaces.AllowedNamespaces = []string{"kube-system"}
+		_, err = p.GetToken(context.Background(), info)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("failed to get secret kube-system/oidc-client")))
+	})
+})
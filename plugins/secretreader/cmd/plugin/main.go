@@ -4,67 +4,79 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	kubernetes "k8s.io/client-go/kubernetes"
 	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
-	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/cluster-inventory-api/pkg/credentialplugin"
 )
 
 type Provider struct {
 	// KubeClient is the typed client for core Kubernetes resources (e.g. Secret).
 	KubeClient kubernetes.Interface
+	// Secrets, if set, is used to read Secrets instead of live requests through KubeClient.
+	Secrets credentialplugin.SecretReader
 	// Namespace, if set, overrides namespace inference.
 	Namespace string
+	// Namespaces restricts the namespaces a ClusterProfile may read Secrets from.
+	Namespaces credentialplugin.NamespaceOptions
+
+	// Envelope controls decryption of encrypted Secret values.
+	Envelope credentialplugin.EnvelopeOptions
 }
 
 // NewDefault constructs a Provider with pre-initialized typed clientsets and an inferred namespace.
 func NewDefault() (*Provider, error) {
-	// Build Kubernetes rest.Config via in-cluster first, then fallback to kubeconfig
-	cfg, err := rest.InClusterConfig()
-	if err != nil {
-		kubeconfig := os.Getenv("KUBECONFIG")
-		cfg, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
-		if err != nil {
-			return nil, fmt.Errorf("failed to build kube client config: %w", err)
-		}
-	}
-
-	kubeClient, err := kubernetes.NewForConfig(cfg)
+	kubeClient, err := credentialplugin.NewKubeClient()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create kubernetes clientset: %w", err)
+		return nil, err
 	}
-
-	return &Provider{KubeClient: kubeClient, Namespace: inferNamespace()}, nil
+	return &Provider{KubeClient: kubeClient, Namespace: credentialplugin.InferNamespace()}, nil
 }
 
 // ProviderName is the name of the credential provider.
 const ProviderName = "secretreader"
 
-// SecretTokenKey is the `Secret.data` key.
+// SecretTokenKey is the default `Secret.data` key.
 const SecretTokenKey = "token"
 
+// SecretExpiresAtKey is the optional `Secret.data` key holding the RFC 3339
+// expiry of the token. It is only consulted when the token is not a JWT with
+// an `exp` claim.
+const SecretExpiresAtKey = "expiresAt"
+
+type execClusterConfig struct {
+	ClusterName   string `json:"clusterName"`   // Cluster name, used to build the Secret name
+	NamePrefix    string `json:"namePrefix"`    // Optional: prepended to clusterName to form the Secret name
+	NameSuffix    string `json:"nameSuffix"`    // Optional: appended to clusterName to form the Secret name
+	LabelSelector string `json:"labelSelector"` // Optional: select the Secret by labels instead of by name
+	Namespace     string `json:"namespace"`     // Optional: namespace to read Secret from
+	Key           string `json:"key"`           // Optional: Secret.data key (defaults to SecretTokenKey)
+}
+
 func (Provider) Name() string { return ProviderName }
 
+// secrets returns the SecretReader used to read Secrets.
+func (p Provider) secrets() credentialplugin.SecretReader {
+	return credentialplugin.SecretReaderOrClient(p.Secrets, p.KubeClient)
+}
+
 func (p Provider) GetToken(
 	ctx context.Context,
 	info clientauthenticationv1.ExecCredential,
 ) (clientauthenticationv1.ExecCredentialStatus, error) {
 	// Require pre-initialized typed clients
-	if p.KubeClient == nil {
+	if p.KubeClient == nil && p.Secrets == nil {
 		return clientauthenticationv1.ExecCredentialStatus{},
 			errors.New("provider clients are not initialized; construct with NewDefault or set clients")
 	}
 
-	// Require clusterName to be present in extensions config
-	type execClusterConfig struct {
-		ClusterName string `json:"clusterName"`
-	}
 	// Validate presence of cluster config
 	if info.Spec.Cluster == nil || len(info.Spec.Cluster.Config.Raw) == 0 {
 		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("missing ExecCredential.Spec.Cluster.Config")
@@ -74,46 +86,158 @@ func (p Provider) GetToken(
 		return clientauthenticationv1.ExecCredentialStatus{},
 			fmt.Errorf("invalid ExecCredential.Spec.Cluster.Config: %w", err)
 	}
-	if cfg.ClusterName == "" {
+	// Require clusterName unless the Secret is selected by labels
+	if cfg.ClusterName == "" && cfg.LabelSelector == "" {
 		return clientauthenticationv1.ExecCredentialStatus{},
 			fmt.Errorf("missing clusterName in ExecCredential.Spec.Cluster.Config")
 	}
-	clusterName := cfg.ClusterName
 
-	// Read Secret <namespace>/<clusterName> via typed client and return token
-	sec, err := p.KubeClient.CoreV1().Secrets(p.Namespace).Get(ctx, clusterName, metav1.GetOptions{})
+	// Determine namespace: use provided namespace, or fallback to inferred namespace
+	namespace := cfg.Namespace
+	if namespace == "" {
+		namespace = p.Namespace
+	}
+	if err := p.Namespaces.Check(namespace, p.Namespace); err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, err
+	}
+	key := cfg.Key
+	if key == "" {
+		key = SecretTokenKey
+	}
+
+	sec, err := p.getSecret(ctx, namespace, cfg)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, err
+	}
+	// Secrets in the rotation layout hold several versions of the token
+	data, notAfter, err := credentialplugin.SecretCredential(sec, key, time.Now())
 	if err != nil {
 		return clientauthenticationv1.ExecCredentialStatus{},
-			fmt.Errorf("failed to get secret %s/%s: %w", p.Namespace, clusterName, err)
+			fmt.Errorf("secret %s/%s: %w", namespace, sec.Name, err)
 	}
-	data, ok := sec.Data[SecretTokenKey]
-	if !ok || len(data) == 0 {
+	data, err = p.Envelope.Open(data)
+	if err != nil {
 		return clientauthenticationv1.ExecCredentialStatus{},
-			fmt.Errorf("secret %s/%s missing %q key", p.Namespace, clusterName, SecretTokenKey)
+			fmt.Errorf("secret %s/%s key %q: %w", namespace, sec.Name, key, err)
+	}
+
+	token := string(data)
+	expiry := notAfter
+	if expiry.IsZero() {
+		expiry, err = tokenExpiry(token, sec.Data)
+		if err != nil {
+			return clientauthenticationv1.ExecCredentialStatus{},
+				fmt.Errorf("secret %s/%s: %w", namespace, sec.Name, err)
+		}
+	} else if exp, err := credentialplugin.JWTExpiry(token); err == nil && exp.Before(expiry) {
+		// The token itself may expire before its version does
+		expiry = exp
 	}
 
-	return clientauthenticationv1.ExecCredentialStatus{Token: string(data)}, nil
+	return clientauthenticationv1.ExecCredentialStatus{
+		Token:               token,
+		ExpirationTimestamp: credentialplugin.ExpirationTimestamp(expiry, credentialplugin.DefaultExpirationSkew),
+	}, nil
 }
 
-// inferNamespace returns the namespace to read Secrets from, preferring the
-// kubeconfig current-context namespace and falling back to the namespace of
-// the Pod this process runs in.
-func inferNamespace() string {
-	rules := clientcmd.NewDefaultClientConfigLoadingRules()
-	if path := os.Getenv("KUBECONFIG"); strings.TrimSpace(path) != "" {
-		rules.ExplicitPath = path
+// getSecret returns the Secret described by cfg: the single Secret matching
+// cfg.LabelSelector if one is set, otherwise the Secret named
+// <namePrefix><clusterName><nameSuffix>.
+func (p Provider) getSecret(ctx context.Context, namespace string, cfg execClusterConfig) (*corev1.Secret, error) {
+	if cfg.LabelSelector == "" {
+		name := cfg.NamePrefix + cfg.ClusterName + cfg.NameSuffix
+		sec, err := p.secrets().GetSecret(ctx, namespace, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get secret %s/%s: %w", namespace, name, err)
+		}
+		return sec, nil
+	}
+
+	selector, err := labels.Parse(cfg.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid labelSelector in ExecCredential.Spec.Cluster.Config: %w", err)
+	}
+	items, err := p.secrets().ListSecrets(ctx, namespace, selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets in %s with selector %q: %w", namespace, selector, err)
 	}
-	cc := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{})
-	if n, _, err := cc.Namespace(); err == nil && strings.TrimSpace(n) != "" {
-		return n
+	switch len(items) {
+	case 0:
+		return nil, fmt.Errorf("no secret in %s matches selector %q", namespace, selector)
+	case 1:
+		return &items[0], nil
+	default:
+		return nil, fmt.Errorf("%d secrets in %s match selector %q; expected exactly one",
+			len(items), namespace, selector)
 	}
-	return "default"
 }
 
-func main() {
-	p, err := NewDefault()
+// tokenExpiry returns when the token expires: the `exp` claim if the token is
+// a JWT, otherwise the optional SecretExpiresAtKey value. A zero time means
+// the expiry is unknown.
+func tokenExpiry(token string, data map[string][]byte) (time.Time, error) {
+	if exp, err := credentialplugin.JWTExpiry(token); err == nil {
+		return exp, nil
+	}
+	raw := strings.TrimSpace(string(data[SecretExpiresAtKey]))
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	exp, err := time.Parse(time.RFC3339, raw)
 	if err != nil {
-		panic(err)
+		return time.Time{}, fmt.Errorf("invalid %q value: %w", SecretExpiresAtKey, err)
+	}
+	return exp, nil
+}
+
+func main() {
+	var envelopeOpts credentialplugin.EnvelopeOptions
+	envelopeOpts.AddFlags(flag.CommandLine)
+	daemon := flag.Bool("daemon", false,
+		"Run as a long-lived daemon that serves requests on --socket from a Secret informer cache.")
+	socket := flag.String("socket", "",
+		"Unix socket of the credential daemon. Without --daemon, requests are forwarded to the daemon "+
+			"and served directly if it is not running.")
+	var namespaceOpts credentialplugin.NamespaceOptions
+	namespaceOpts.AddFlags(flag.CommandLine)
+	flag.Parse()
+
+	newProvider := func() (*Provider, error) {
+		p, err := NewDefault()
+		if err != nil {
+			return nil, err
+		}
+		p.Envelope = envelopeOpts
+		p.Namespaces = namespaceOpts
+		return p, nil
+	}
+
+	switch {
+	case *daemon:
+		if *socket == "" {
+			fmt.Fprintln(os.Stderr, "["+ProviderName+"] --daemon requires --socket")
+			os.Exit(1)
+		}
+		p, err := newProvider()
+		if err != nil {
+			panic(err)
+		}
+		p.Secrets = credentialplugin.NewCachedSecretReader(context.Background(), p.KubeClient, 0,
+			p.Namespaces.Namespaces(p.Namespace))
+		credentialplugin.RunDaemon(*p, *socket)
+	case *socket != "":
+		credentialplugin.RunShim(ProviderName, *socket, func() (credentialplugin.Provider, error) {
+			p, err := newProvider()
+			if err != nil {
+				return nil, err
+			}
+			return *p, nil
+		})
+	default:
+		p, err := newProvider()
+		if err != nil {
+			panic(err)
+		}
+		credentialplugin.Run(*p)
 	}
-	credentialplugin.Run(*p)
 }
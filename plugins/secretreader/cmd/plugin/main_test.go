@@ -0,0 +1,383 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+	"sigs.k8s.io/cluster-inventory-api/pkg/credentialplugin"
+)
+
+// pluginMainEnv makes the test binary run main instead of the tests, so that
+// the tests can exec it as the plugin.
+const pluginMainEnv = "SECRETREADER_TEST_RUN_MAIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(pluginMainEnv) != "" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestSecretReader(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "Secret Reader Plugin Suite")
+}
+
+func unsignedJWT(exp time.Time) string {
+	enc := base64.RawURLEncoding
+	claims := `{"sub":"controller","exp":` + strconv.FormatInt(exp.Unix(), 10) + `}`
+	return enc.EncodeToString([]byte(`{"alg":"none"}`)) + "." + enc.EncodeToString([]byte(claims)) + ".sig"
+}
+
+func newProvider(secrets ...*corev1.Secret) Provider {
+	objs := make([]runtime.Object, 0, len(secrets))
+	for _, sec := range secrets {
+		objs = append(objs, sec)
+	}
+	return Provider{KubeClient: fake.NewClientset(objs...), Namespace: "default"}
+}
+
+func tokenSecret(name string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Data:       data,
+	}
+}
+
+func execInfo(config string) clientauthenticationv1.ExecCredential {
+	return clientauthenticationv1.ExecCredential{
+		Spec: clientauthenticationv1.ExecCredentialSpec{
+			Cluster: &clientauthenticationv1.Cluster{
+				Server: "https://spoke.example.com",
+				Config: runtime.RawExtension{Raw: []byte(config)},
+			},
+		},
+	}
+}
+
+var _ = ginkgo.Describe("Provider", func() {
+	ginkgo.Describe("Secret lookup", func() {
+		labeled := func(name, namespace string, lbls map[string]string, token string) *corev1.Secret {
+			return &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: lbls},
+				Data:       map[string][]byte{"token": []byte(token), "alt": []byte(token + "-alt")},
+			}
+		}
+		secrets := []*corev1.Secret{
+			labeled("spoke-1", "default", nil, "default-token"),
+			labeled("spoke-1", "credentials", nil, "credentials-token"),
+			labeled("cp-spoke-1", "default", nil, "prefix-token"),
+			labeled("spoke-1-token", "default", nil, "suffix-token"),
+			labeled("cp-spoke-1-token", "default", nil, "prefix-suffix-token"),
+			labeled("by-label", "default", map[string]string{"cluster": "spoke-2"}, "label-token"),
+			labeled("dup-1", "default", map[string]string{"cluster": "spoke-3"}, "dup-token"),
+			labeled("dup-2", "default", map[string]string{"cluster": "spoke-3"}, "dup-token"),
+			labeled("other-ns", "credentials", map[string]string{"cluster": "spoke-2"}, "label-ns-token"),
+		}
+
+		ginkgo.DescribeTable("should read the token of the configured Secret",
+			func(config, token string) {
+				p := newProvider(secrets...)
+				p.Namespaces.AllowedNamespaces = []string{"default", "credentials"}
+				status, err := p.GetToken(context.Background(), execInfo(config))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(status.Token).To(gomega.Equal(token))
+			},
+			ginkgo.Entry("clusterName only", `{"clusterName":"spoke-1"}`, "default-token"),
+			ginkgo.Entry("namespace", `{"clusterName":"spoke-1","namespace":"credentials"}`, "credentials-token"),
+			ginkgo.Entry("key", `{"clusterName":"spoke-1","key":"alt"}`, "default-token-alt"),
+			ginkgo.Entry("namePrefix", `{"clusterName":"spoke-1","namePrefix":"cp-"}`, "prefix-token"),
+			ginkgo.Entry("nameSuffix", `{"clusterName":"spoke-1","nameSuffix":"-token"}`, "suffix-token"),
+			ginkgo.Entry("namePrefix and nameSuffix",
+				`{"clusterName":"spoke-1","namePrefix":"cp-","nameSuffix":"-token"}`, "prefix-suffix-token"),
+			ginkgo.Entry("labelSelector", `{"labelSelector":"cluster=spoke-2"}`, "label-token"),
+			ginkgo.Entry("labelSelector takes precedence over the name",
+				`{"clusterName":"spoke-1","labelSelector":"cluster=spoke-2"}`, "label-token"),
+			ginkgo.Entry("labelSelector and namespace",
+				`{"labelSelector":"cluster=spoke-2","namespace":"credentials"}`, "label-ns-token"),
+		)
+
+		ginkgo.DescribeTable("should reject",
+			func(config, message string) {
+				_, err := newProvider(secrets...).GetToken(context.Background(), execInfo(config))
+				gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(message)))
+			},
+			ginkgo.Entry("a config without clusterName or labelSelector", `{"namespace":"default"}`,
+				"missing clusterName"),
+			ginkgo.Entry("a missing Secret", `{"clusterName":"spoke-9"}`, "failed to get secret default/spoke-9"),
+			ginkgo.Entry("a missing key", `{"clusterName":"spoke-1","key":"missing"}`, `"missing"`),
+			ginkgo.Entry("an invalid labelSelector", `{"labelSelector":"cluster in spoke-2"}`, "invalid labelSelector"),
+			ginkgo.Entry("a labelSelector matching no Secret", `{"labelSelector":"cluster=spoke-9"}`,
+				"no secret in default matches selector"),
+			ginkgo.Entry("a labelSelector matching several Secrets", `{"labelSelector":"cluster=spoke-3"}`,
+				"2 secrets in default match selector"),
+			ginkgo.Entry("a namespace other than the inferred one", `{"clusterName":"spoke-1","namespace":"credentials"}`,
+				`namespace "credentials" is not allowed`),
+			ginkgo.Entry("a namespace other than the inferred one with labelSelector",
+				`{"labelSelector":"cluster=spoke-2","namespace":"credentials"}`, `namespace "credentials" is not allowed`),
+		)
+	})
+
+	ginkgo.It("should only read the allowed namespaces when they are set", func() {
+		client := fake.NewClientset()
+		p := Provider{KubeClient: client, Namespace: "default"}
+		p.Namespaces.AllowedNamespaces = []string{"credentials"}
+
+		_, err := p.GetToken(context.Background(), execInfo(`{"clusterName":"spoke-1"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`namespace "default" is not allowed`)))
+		gomega.Expect(client.Actions()).To(gomega.BeEmpty())
+	})
+
+	ginkgo.Describe("token expiry", func() {
+		var exp time.Time
+
+		ginkgo.BeforeEach(func() {
+			exp = time.Now().Add(time.Hour).Truncate(time.Second)
+		})
+
+		ginkgo.It("should expire with the exp claim of a JWT, minus the skew", func() {
+			token := unsignedJWT(exp)
+			p := newProvider(tokenSecret("spoke-1", map[string][]byte{"token": []byte(token)}))
+			status, err := p.GetToken(context.Background(), execInfo(`{"clusterName":"spoke-1"}`))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(status.Token).To(gomega.Equal(token))
+			gomega.Expect(status.ExpirationTimestamp).NotTo(gomega.BeNil())
+			gomega.Expect(status.ExpirationTimestamp.Time).To(gomega.BeTemporally("==", exp.Add(-30*time.Second)))
+		})
+
+		ginkgo.It("should prefer the exp claim over expiresAt", func() {
+			p := newProvider(tokenSecret("spoke-1", map[string][]byte{
+				"token":     []byte(unsignedJWT(exp)),
+				"expiresAt": []byte(exp.Add(time.Hour).UTC().Format(time.RFC3339)),
+			}))
+			status, err := p.GetToken(context.Background(), execInfo(`{"clusterName":"spoke-1"}`))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(status.ExpirationTimestamp.Time).To(gomega.BeTemporally("==", exp.Add(-30*time.Second)))
+		})
+
+		ginkgo.It("should fall back to expiresAt for opaque tokens", func() {
+			p := newProvider(tokenSecret("spoke-1", map[string][]byte{
+				"token":     []byte("opaque-token"),
+				"expiresAt": []byte(exp.UTC().Format(time.RFC3339)),
+			}))
+			status, err := p.GetToken(context.Background(), execInfo(`{"clusterName":"spoke-1"}`))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(status.Token).To(gomega.Equal("opaque-token"))
+			gomega.Expect(status.ExpirationTimestamp.Time).To(gomega.BeTemporally("==", exp.Add(-30*time.Second)))
+		})
+
+		ginkgo.It("should not apply the skew to a token that expires sooner than the skew", func() {
+			soon := time.Now().Add(10 * time.Second).Truncate(time.Second).Add(time.Second)
+			p := newProvider(tokenSecret("spoke-1", map[string][]byte{
+				"token":     []byte("opaque-token"),
+				"expiresAt": []byte(soon.UTC().Format(time.RFC3339)),
+			}))
+			status, err := p.GetToken(context.Background(), execInfo(`{"clusterName":"spoke-1"}`))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(status.ExpirationTimestamp.Time).To(gomega.BeTemporally("==", soon))
+		})
+
+		ginkgo.It("should leave the expiry unset when it is unknown", func() {
+			p := newProvider(tokenSecret("spoke-1", map[string][]byte{"token": []byte("opaque-token")}))
+			status, err := p.GetToken(context.Background(), execInfo(`{"clusterName":"spoke-1"}`))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(status.ExpirationTimestamp).To(gomega.BeNil())
+		})
+
+		ginkgo.It("should reject an invalid expiresAt", func() {
+			p := newProvider(tokenSecret("spoke-1", map[string][]byte{
+				"token":     []byte("opaque-token"),
+				"expiresAt": []byte("tomorrow"),
+			}))
+			_, err := p.GetToken(context.Background(), execInfo(`{"clusterName":"spoke-1"}`))
+			gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`invalid "expiresAt" value`)))
+		})
+	})
+
+	ginkgo.Describe("rotation layout", func() {
+		var now time.Time
+
+		rfc3339 := func(t time.Time) []byte { return []byte(t.UTC().Format(time.RFC3339)) }
+
+		ginkgo.BeforeEach(func() {
+			now = time.Now().Truncate(time.Second)
+		})
+
+		ginkgo.It("should return the newest valid version and expire with it", func() {
+			notAfter := now.Add(time.Hour)
+			p := newProvider(tokenSecret("spoke-1", map[string][]byte{
+				"token.1":           []byte("old-token"),
+				"token.2":           []byte("new-token"),
+				"token.2.notBefore": rfc3339(now.Add(-time.Minute)),
+				"token.2.notAfter":  rfc3339(notAfter),
+				"token.3":           []byte("pending-token"),
+				"token.3.notBefore": rfc3339(now.Add(time.Hour)),
+			}))
+			status, err := p.GetToken(context.Background(), execInfo(`{"clusterName":"spoke-1"}`))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(status.Token).To(gomega.Equal("new-token"))
+			gomega.Expect(status.ExpirationTimestamp.Time).To(gomega.BeTemporally("==", notAfter.Add(-30*time.Second)))
+		})
+
+		ginkgo.It("should expire with the JWT if it expires before its version", func() {
+			exp := now.Add(time.Hour)
+			p := newProvider(tokenSecret("spoke-1", map[string][]byte{
+				"token.1":          []byte(unsignedJWT(exp)),
+				"token.1.notAfter": rfc3339(exp.Add(time.Hour)),
+			}))
+			status, err := p.GetToken(context.Background(), execInfo(`{"clusterName":"spoke-1"}`))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(status.ExpirationTimestamp.Time).To(gomega.BeTemporally("==", exp.Add(-30*time.Second)))
+		})
+
+		ginkgo.It("should expire with the version if it expires before the JWT", func() {
+			notAfter := now.Add(time.Hour)
+			p := newProvider(tokenSecret("spoke-1", map[string][]byte{
+				"token.1":          []byte(unsignedJWT(notAfter.Add(time.Hour))),
+				"token.1.notAfter": rfc3339(notAfter),
+			}))
+			status, err := p.GetToken(context.Background(), execInfo(`{"clusterName":"spoke-1"}`))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(status.ExpirationTimestamp.Time).To(gomega.BeTemporally("==", notAfter.Add(-30*time.Second)))
+		})
+
+		ginkgo.It("should use the configured key", func() {
+			p := newProvider(tokenSecret("spoke-1", map[string][]byte{
+				"alt.1": []byte("alt-token"),
+			}))
+			status, err := p.GetToken(context.Background(), execInfo(`{"clusterName":"spoke-1","key":"alt"}`))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(status.Token).To(gomega.Equal("alt-token"))
+		})
+
+		ginkgo.It("should reject a Secret without a valid version", func() {
+			p := newProvider(tokenSecret("spoke-1", map[string][]byte{
+				"token.1":          []byte("expired-token"),
+				"token.1.notAfter": rfc3339(now.Add(-time.Minute)),
+			}))
+			_, err := p.GetToken(context.Background(), execInfo(`{"clusterName":"spoke-1"}`))
+			gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("none of the 1 versions")))
+		})
+	})
+
+	ginkgo.Describe("shim mode", func() {
+		var (
+			tempDir string
+			env     []string
+		)
+
+		// runPlugin execs the plugin with args and returns the token it printed.
+		runPlugin := func(args ...string) string {
+			cmd := exec.Command(os.Args[0], args...)
+			cmd.Env = env
+			var stderr bytes.Buffer
+			cmd.Stderr = &stderr
+			out, err := cmd.Output()
+			gomega.Expect(err).NotTo(gomega.HaveOccurred(), stderr.String())
+			var ec clientauthenticationv1.ExecCredential
+			gomega.Expect(json.Unmarshal(out, &ec)).To(gomega.Succeed())
+			gomega.Expect(ec.Status).NotTo(gomega.BeNil())
+			return ec.Status.Token
+		}
+
+		ginkgo.BeforeEach(func() {
+			tempDir = ginkgo.GinkgoT().TempDir()
+
+			// The API server serves the token read in direct mode
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/namespaces/default/secrets/spoke-1" {
+					http.NotFound(w, r)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(corev1.Secret{
+					TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+					ObjectMeta: metav1.ObjectMeta{Name: "spoke-1", Namespace: "default"},
+					Data:       map[string][]byte{"token": []byte("direct-token")},
+				})
+			}))
+			ginkgo.DeferCleanup(server.Close)
+
+			kubeconfig := filepath.Join(tempDir, "kubeconfig")
+			gomega.Expect(os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: hub
+  cluster:
+    server: `+server.URL+`
+contexts:
+- name: hub
+  context:
+    cluster: hub
+    namespace: default
+current-context: hub
+`), 0o600)).To(gomega.Succeed())
+
+			env = []string{
+				pluginMainEnv + "=1",
+				"KUBECONFIG=" + kubeconfig,
+				`KUBERNETES_EXEC_INFO={"apiVersion":"client.authentication.k8s.io/v1","kind":"ExecCredential",` +
+					`"spec":{"interactive":false,"cluster":{"server":"https://spoke.example.com",` +
+					`"config":{"clusterName":"spoke-1"}}}}`,
+			}
+		})
+
+		ginkgo.It("should read the Secret directly when the daemon is not running", func() {
+			token := runPlugin("--socket=" + filepath.Join(tempDir, "missing.sock"))
+			gomega.Expect(token).To(gomega.Equal("direct-token"))
+		})
+
+		ginkgo.It("should forward the request to a running daemon", func() {
+			socketPath := filepath.Join(tempDir, "secretreader.sock")
+			ctx, cancel := context.WithCancel(context.Background())
+			ginkgo.DeferCleanup(cancel)
+			client := fake.NewClientset(tokenSecret("spoke-1", map[string][]byte{"token": []byte("daemon-token")}))
+			p := Provider{
+				KubeClient: client,
+				Secrets:    credentialplugin.NewCachedSecretReader(ctx, client, 0, []string{"default"}),
+				Namespace:  "default",
+			}
+			go func() { _ = credentialplugin.Serve(ctx, socketPath, p) }()
+			gomega.Eventually(func() error {
+				_, err := os.Stat(socketPath)
+				return err
+			}).Should(gomega.Succeed())
+
+			gomega.Expect(runPlugin("--socket=" + socketPath)).To(gomega.Equal("daemon-token"))
+		})
+	})
+})
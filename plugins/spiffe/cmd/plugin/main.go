@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"github.com/spiffe/go-spiffe/v2/workloadapi"
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+	"sigs.k8s.io/cluster-inventory-api/pkg/credentialplugin"
+)
+
+// ProviderName is the name of the credential provider.
+const ProviderName = "spiffe"
+
+const (
+	// fetchTimeout bounds how long to wait for the Workload API, which blocks
+	// until the workload has been attested.
+	fetchTimeout = 30 * time.Second
+	// dialTimeout bounds the TLS handshake that checks the spoke identity.
+	dialTimeout = 10 * time.Second
+	// svidExpirySkew is subtracted from the SVID expiry. SVIDs are short-lived
+	// and rotated at half of their lifetime, so a larger skew is not needed.
+	svidExpirySkew = 30 * time.Second
+)
+
+type Provider struct {
+	// SocketPath is the Workload API address, a socket path or unix:// URL.
+	// Defaults to $SPIFFE_ENDPOINT_SOCKET.
+	SocketPath string
+}
+
+type execClusterConfig struct {
+	SocketPath  string `json:"socketPath"`  // Optional: overrides the Workload API socket of the plugin
+	TrustDomain string `json:"trustDomain"` // Optional: only an SVID of this trust domain is returned
+	SpokeID     string `json:"spokeID"`     // Optional: SPIFFE ID the spoke API server must present
+}
+
+func (Provider) Name() string { return ProviderName }
+
+func (p Provider) GetToken(
+	ctx context.Context,
+	info clientauthenticationv1.ExecCredential,
+) (clientauthenticationv1.ExecCredentialStatus, error) {
+	var cfg execClusterConfig
+	if info.Spec.Cluster != nil && len(info.Spec.Cluster.Config.Raw) > 0 {
+		if err := json.Unmarshal(info.Spec.Cluster.Config.Raw, &cfg); err != nil {
+			return clientauthenticationv1.ExecCredentialStatus{},
+				fmt.Errorf("invalid ExecCredential.Spec.Cluster.Config: %w", err)
+		}
+	}
+	var trustDomain spiffeid.TrustDomain
+	if cfg.TrustDomain != "" {
+		td, err := spiffeid.TrustDomainFromString(cfg.TrustDomain)
+		if err != nil {
+			return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("invalid trustDomain %q: %w", cfg.TrustDomain, err)
+		}
+		trustDomain = td
+	}
+	var spokeID spiffeid.ID
+	if cfg.SpokeID != "" {
+		id, err := spiffeid.FromString(cfg.SpokeID)
+		if err != nil {
+			return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("invalid spokeID %q: %w", cfg.SpokeID, err)
+		}
+		spokeID = id
+	}
+
+	addr := cfg.SocketPath
+	if addr == "" {
+		addr = p.SocketPath
+	}
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+	x509Context, err := fetchX509Context(ctx, addr)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, err
+	}
+
+	svid, err := selectSVID(x509Context.SVIDs, trustDomain)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, err
+	}
+
+	// Only hand out the SVID for a spoke that proves the expected identity
+	if !spokeID.IsZero() {
+		if info.Spec.Cluster == nil {
+			return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("spokeID requires ExecCredential.Spec.Cluster")
+		}
+		if err := verifySpoke(ctx, info.Spec.Cluster.Server, x509Context, spokeID); err != nil {
+			return clientauthenticationv1.ExecCredentialStatus{}, err
+		}
+	}
+
+	certPEM, keyPEM, err := svid.Marshal()
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("failed to encode SVID %s: %w", svid.ID, err)
+	}
+	return clientauthenticationv1.ExecCredentialStatus{
+		ClientCertificateData: string(certPEM),
+		ClientKeyData:         string(keyPEM),
+		ExpirationTimestamp:   credentialplugin.ExpirationTimestamp(svid.Certificates[0].NotAfter, svidExpirySkew),
+	}, nil
+}
+
+// fetchX509Context fetches the SVIDs and bundles of this workload.
+func fetchX509Context(ctx context.Context, addr string) (*workloadapi.X509Context, error) {
+	var opts []workloadapi.ClientOption
+	if addr != "" {
+		if !strings.Contains(addr, "://") {
+			addr = "unix://" + addr
+		}
+		opts = append(opts, workloadapi.WithAddr(addr))
+	}
+	client, err := workloadapi.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Workload API client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	x509Context, err := client.FetchX509Context(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch X.509 SVID from the Workload API: %w", err)
+	}
+	return x509Context, nil
+}
+
+// selectSVID returns the first SVID, or the first one of trustDomain if set.
+func selectSVID(svids []*x509svid.SVID, trustDomain spiffeid.TrustDomain) (*x509svid.SVID, error) {
+	for _, svid := range svids {
+		if trustDomain.IsZero() || svid.ID.MemberOf(trustDomain) {
+			return svid, nil
+		}
+	}
+	if trustDomain.IsZero() {
+		return nil, fmt.Errorf("the Workload API returned no X.509 SVID")
+	}
+	return nil, fmt.Errorf("the Workload API returned no X.509 SVID in trust domain %q", trustDomain)
+}
+
+// verifySpoke performs a TLS handshake with the spoke API server and checks
+// that it presents an X.509 SVID for spokeID, verified with the bundles of
+// the Workload API.
+func verifySpoke(ctx context.Context, server string, x509Context *workloadapi.X509Context, spokeID spiffeid.ID) error {
+	u, err := url.Parse(server)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid spoke server %q", server)
+	}
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), "443")
+	}
+
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: dialTimeout},
+		Config:    tlsconfig.TLSClientConfig(x509Context.Bundles, tlsconfig.AuthorizeID(spokeID)),
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return fmt.Errorf("spoke server %s did not present SPIFFE ID %s: %w", server, spokeID, err)
+	}
+	return conn.Close()
+}
+
+func main() {
+	socketPath := flag.String("socket-path", os.Getenv("SPIFFE_ENDPOINT_SOCKET"),
+		"Workload API socket path or unix:// URL. Defaults to $SPIFFE_ENDPOINT_SOCKET.")
+	flag.Parse()
+
+	credentialplugin.Run(Provider{SocketPath: *socketPath})
+}
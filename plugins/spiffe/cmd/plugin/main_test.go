@@ -0,0 +1,262 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"github.com/spiffe/go-spiffe/v2/proto/spiffe/workload"
+	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+)
+
+func TestSPIFFE(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "SPIFFE Plugin Suite")
+}
+
+// testCA issues certificates carrying SPIFFE IDs as URI SANs.
+type testCA struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func newTestCA(trustDomain string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: trustDomain},
+		URIs:                  []*url.URL{{Scheme: "spiffe", Host: trustDomain}},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	cert, err := x509.ParseCertificate(der)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	return &testCA{cert: cert, key: key}
+}
+
+// issue returns a leaf certificate for id and its key.
+func (ca *testCA) issue(id string, notAfter time.Time) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	u, err := url.Parse(id)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		URIs:                  []*url.URL{u},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              notAfter,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, key.Public(), ca.key)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	cert, err := x509.ParseCertificate(der)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	return cert, key
+}
+
+// fakeWorkloadAPI serves the X.509 SVID stream of the SPIFFE Workload API.
+type fakeWorkloadAPI struct {
+	workload.UnimplementedSpiffeWorkloadAPIServer
+	response *workload.X509SVIDResponse
+}
+
+func (f *fakeWorkloadAPI) FetchX509SVID(_ *workload.X509SVIDRequest, stream grpc.ServerStreamingServer[workload.X509SVIDResponse]) error {
+	if err := stream.Send(f.response); err != nil {
+		return err
+	}
+	<-stream.Context().Done()
+	return nil
+}
+
+// serveWorkloadAPI starts api on a Unix socket and returns its path.
+func serveWorkloadAPI(api *fakeWorkloadAPI) string {
+	// Unix socket paths are limited to ~100 bytes, so avoid the long ginkgo temp dirs
+	dir, err := os.MkdirTemp("", "spiffe")
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	ginkgo.DeferCleanup(os.RemoveAll, dir)
+	socketPath := filepath.Join(dir, "agent.sock")
+
+	lis, err := net.Listen("unix", socketPath)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	server := grpc.NewServer()
+	workload.RegisterSpiffeWorkloadAPIServer(server, api)
+	go func() { _ = server.Serve(lis) }()
+	ginkgo.DeferCleanup(server.Stop)
+	return socketPath
+}
+
+func x509SVID(id string, cert *x509.Certificate, key *ecdsa.PrivateKey, bundle *x509.Certificate) *workload.X509SVID {
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	return &workload.X509SVID{SpiffeId: id, X509Svid: cert.Raw, X509SvidKey: keyDER, Bundle: bundle.Raw}
+}
+
+func execInfo(server, config string) clientauthenticationv1.ExecCredential {
+	return clientauthenticationv1.ExecCredential{
+		Spec: clientauthenticationv1.ExecCredentialSpec{
+			Cluster: &clientauthenticationv1.Cluster{
+				Server: server,
+				Config: runtime.RawExtension{Raw: []byte(config)},
+			},
+		},
+	}
+}
+
+var _ = ginkgo.Describe("Provider", func() {
+	var (
+		hubCA, spokeCA *testCA
+		svidExpiry     time.Time
+		api            *fakeWorkloadAPI
+		p              Provider
+	)
+
+	ginkgo.BeforeEach(func() {
+		hubCA = newTestCA("example.org")
+		spokeCA = newTestCA("spoke.example")
+		svidExpiry = time.Now().Add(time.Hour).Truncate(time.Second)
+		cert, key := hubCA.issue("spiffe://example.org/hub", svidExpiry)
+		api = &fakeWorkloadAPI{response: &workload.X509SVIDResponse{
+			Svids: []*workload.X509SVID{x509SVID("spiffe://example.org/hub", cert, key, hubCA.cert)},
+			FederatedBundles: map[string][]byte{
+				"spiffe://spoke.example": spokeCA.cert.Raw,
+			},
+		}}
+		p = Provider{SocketPath: serveWorkloadAPI(api)}
+	})
+
+	// spokeServer starts a TLS server presenting a certificate for id.
+	spokeServer := func(id string) *httptest.Server {
+		cert, key := spokeCA.issue(id, time.Now().Add(time.Hour))
+		server := httptest.NewUnstartedServer(http.NotFoundHandler())
+		server.TLS = &tls.Config{Certificates: []tls.Certificate{{
+			Certificate: [][]byte{cert.Raw},
+			PrivateKey:  key,
+		}}}
+		server.StartTLS()
+		ginkgo.DeferCleanup(server.Close)
+		return server
+	}
+
+	ginkgo.It("should return the X.509 SVID as client certificate with its expiry", func() {
+		status, err := p.GetToken(context.Background(), execInfo("https://spoke.example.com", `{}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(status.Token).To(gomega.BeEmpty())
+		gomega.Expect(status.ExpirationTimestamp).NotTo(gomega.BeNil())
+		gomega.Expect(status.ExpirationTimestamp.Time).To(gomega.BeTemporally("==", svidExpiry.Add(-30*time.Second)))
+
+		block, _ := pem.Decode([]byte(status.ClientCertificateData))
+		gomega.Expect(block).NotTo(gomega.BeNil())
+		cert, err := x509.ParseCertificate(block.Bytes)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(cert.URIs[0].String()).To(gomega.Equal("spiffe://example.org/hub"))
+
+		_, err = tls.X509KeyPair([]byte(status.ClientCertificateData), []byte(status.ClientKeyData))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	ginkgo.It("should use the socket path of the cluster config", func() {
+		socketPath := p.SocketPath
+		p.SocketPath = "/nonexistent/agent.sock"
+
+		_, err := p.GetToken(context.Background(), execInfo("https://spoke.example.com",
+			fmt.Sprintf(`{"socketPath": "unix://%s"}`, socketPath)))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	ginkgo.It("should select the SVID of the configured trust domain", func() {
+		otherCA := newTestCA("other.example")
+		otherCert, otherKey := otherCA.issue("spiffe://other.example/hub", time.Now().Add(time.Hour))
+		api.response.Svids = append([]*workload.X509SVID{
+			x509SVID("spiffe://other.example/hub", otherCert, otherKey, otherCA.cert),
+		}, api.response.Svids...)
+
+		status, err := p.GetToken(context.Background(), execInfo("https://spoke.example.com",
+			`{"trustDomain": "example.org"}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		block, _ := pem.Decode([]byte(status.ClientCertificateData))
+		cert, err := x509.ParseCertificate(block.Bytes)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(cert.URIs[0].String()).To(gomega.Equal("spiffe://example.org/hub"))
+
+		_, err = p.GetToken(context.Background(), execInfo("https://spoke.example.com",
+			`{"trustDomain": "missing.example"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`no X.509 SVID in trust domain "missing.example"`)))
+	})
+
+	ginkgo.It("should return the SVID if the spoke presents the expected SPIFFE ID", func() {
+		server := spokeServer("spiffe://spoke.example/kube-apiserver")
+
+		status, err := p.GetToken(context.Background(), execInfo(server.URL,
+			`{"spokeID": "spiffe://spoke.example/kube-apiserver"}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(status.ClientCertificateData).NotTo(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should reject a spoke presenting another SPIFFE ID", func() {
+		server := spokeServer("spiffe://spoke.example/impostor")
+
+		status, err := p.GetToken(context.Background(), execInfo(server.URL,
+			`{"spokeID": "spiffe://spoke.example/kube-apiserver"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("did not present SPIFFE ID spiffe://spoke.example/kube-apiserver")))
+		gomega.Expect(status.ClientKeyData).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should reject a spoke not verified by the federated bundle", func() {
+		server := httptest.NewTLSServer(http.NotFoundHandler())
+		ginkgo.DeferCleanup(server.Close)
+
+		_, err := p.GetToken(context.Background(), execInfo(server.URL,
+			`{"spokeID": "spiffe://spoke.example/kube-apiserver"}`))
+		gomega.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	ginkgo.It("should reject an invalid spokeID", func() {
+		_, err := p.GetToken(context.Background(), execInfo("https://spoke.example.com",
+			`{"spokeID": "https://spoke.example"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("invalid spokeID")))
+	})
+})
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+	"sigs.k8s.io/cluster-inventory-api/pkg/credentialplugin"
+)
+
+// ProviderName is the name of the credential provider.
+const ProviderName = "token-exchange"
+
+// Token type identifiers defined in RFC 8693, section 3.
+const (
+	tokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+	tokenTypeJWT         = "urn:ietf:params:oauth:token-type:jwt"
+	grantTypeExchange    = "urn:ietf:params:oauth:grant-type:token-exchange"
+)
+
+// maxResponseSize bounds the size of the STS response body.
+const maxResponseSize = 1 << 20
+
+type Provider struct {
+	// SubjectTokenFile is the path of the token presented to the STS, typically a
+	// projected ServiceAccount token whose audience is the STS. It is re-read on
+	// every call so that kubelet rotations are picked up.
+	SubjectTokenFile string
+	// AllowedTokenURLs lists the only STS endpoints the subject token may be
+	// sent to. If empty, every request is refused.
+	AllowedTokenURLs []string
+	// HTTPClient is used for token requests. Defaults to a client with credentialplugin.HTTPTimeout.
+	HTTPClient *http.Client
+}
+
+type execClusterConfig struct {
+	TokenURL           string   `json:"tokenURL"`           // STS token endpoint (required)
+	Audience           string   `json:"audience"`           // Optional: logical name of the target service
+	Resource           string   `json:"resource"`           // Optional: URI of the target service, e.g. the spoke API server
+	Scopes             []string `json:"scopes"`             // Optional: requested scopes
+	ClientID           string   `json:"clientID"`           // Optional: client_id sent with the request
+	SubjectTokenType   string   `json:"subjectTokenType"`   // Optional: defaults to the JWT token type
+	RequestedTokenType string   `json:"requestedTokenType"` // Optional: defaults to the access token type
+}
+
+// tokenExchangeResponse is the successful response of RFC 8693, section 2.2.1.
+type tokenExchangeResponse struct {
+	AccessToken     string `json:"access_token"`
+	IssuedTokenType string `json:"issued_token_type"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int64  `json:"expires_in"`
+}
+
+// errorResponse is the error response of RFC 6749, section 5.2.
+type errorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (Provider) Name() string { return ProviderName }
+
+func (p Provider) GetToken(
+	ctx context.Context,
+	info clientauthenticationv1.ExecCredential,
+) (clientauthenticationv1.ExecCredentialStatus, error) {
+	if p.SubjectTokenFile == "" {
+		return clientauthenticationv1.ExecCredentialStatus{},
+			errors.New("subject token file is not configured; set --subject-token-file")
+	}
+	// The token URL comes from the ClusterProfile, so without an allowlist a
+	// cluster manager could collect the subject token.
+	if len(p.AllowedTokenURLs) == 0 {
+		return clientauthenticationv1.ExecCredentialStatus{},
+			errors.New("no STS endpoint is allowed; set --allowed-token-url")
+	}
+
+	// Validate presence of cluster config
+	if info.Spec.Cluster == nil || len(info.Spec.Cluster.Config.Raw) == 0 {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("missing ExecCredential.Spec.Cluster.Config")
+	}
+	var cfg execClusterConfig
+	if err := json.Unmarshal(info.Spec.Cluster.Config.Raw, &cfg); err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{},
+			fmt.Errorf("invalid ExecCredential.Spec.Cluster.Config: %w", err)
+	}
+	if err := p.validateTokenURL(cfg.TokenURL); err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, err
+	}
+
+	subjectToken, err := os.ReadFile(p.SubjectTokenFile)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("failed to read subject token: %w", err)
+	}
+
+	form := url.Values{
+		"grant_type":           {grantTypeExchange},
+		"subject_token":        {strings.TrimSpace(string(subjectToken))},
+		"subject_token_type":   {defaultString(cfg.SubjectTokenType, tokenTypeJWT)},
+		"requested_token_type": {defaultString(cfg.RequestedTokenType, tokenTypeAccessToken)},
+	}
+	if cfg.Audience != "" {
+		form.Set("audience", cfg.Audience)
+	}
+	if cfg.Resource != "" {
+		form.Set("resource", cfg.Resource)
+	}
+	if len(cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(cfg.Scopes, " "))
+	}
+	if cfg.ClientID != "" {
+		form.Set("client_id", cfg.ClientID)
+	}
+
+	start := time.Now()
+	resp, err := p.exchange(ctx, cfg.TokenURL, form)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, err
+	}
+	if resp.AccessToken == "" {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("token exchange response has no access_token")
+	}
+	if tt := strings.ToLower(resp.TokenType); tt != "" && tt != "bearer" && tt != "n_a" {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("unsupported token_type %q", resp.TokenType)
+	}
+
+	// Prefer expires_in, then the exp claim of a JWT
+	var expiry time.Time
+	if resp.ExpiresIn > 0 {
+		expiry = start.Add(time.Duration(resp.ExpiresIn) * time.Second)
+	} else if exp, err := credentialplugin.JWTExpiry(resp.AccessToken); err == nil {
+		expiry = exp
+	}
+
+	return clientauthenticationv1.ExecCredentialStatus{
+		Token:               resp.AccessToken,
+		ExpirationTimestamp: credentialplugin.ExpirationTimestamp(expiry, credentialplugin.DefaultExpirationSkew),
+	}, nil
+}
+
+// validateTokenURL checks that the subject token may be sent to tokenURL.
+func (p Provider) validateTokenURL(tokenURL string) error {
+	if tokenURL == "" {
+		return fmt.Errorf("missing tokenURL in ExecCredential.Spec.Cluster.Config")
+	}
+	u, err := url.Parse(tokenURL)
+	if err != nil {
+		return fmt.Errorf("invalid tokenURL: %w", err)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("invalid tokenURL: %q is not an https URL", tokenURL)
+	}
+	if !slices.Contains(p.AllowedTokenURLs, tokenURL) {
+		return fmt.Errorf("tokenURL %q is not allowed", tokenURL)
+	}
+	return nil
+}
+
+// exchange posts the token exchange request and decodes the response.
+func (p Provider) exchange(ctx context.Context, tokenURL string, form url.Values) (*tokenExchangeResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build token exchange request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	client := p.HTTPClient
+	if client == nil {
+		client = credentialplugin.NewHTTPClient(nil)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token exchange request to %s failed: %w", tokenURL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token exchange response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var e errorResponse
+		if json.Unmarshal(body, &e) == nil && e.Error != "" {
+			return nil, fmt.Errorf("token exchange at %s failed: %s: %s", tokenURL, e.Error, e.ErrorDescription)
+		}
+		return nil, fmt.Errorf("token exchange at %s failed: unexpected status %s", tokenURL, resp.Status)
+	}
+	var out tokenExchangeResponse
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, fmt.Errorf("failed to parse token exchange response: %w", err)
+	}
+	return &out, nil
+}
+
+func defaultString(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
+
+func main() {
+	subjectTokenFile := flag.String("subject-token-file", "",
+		"Path of the token presented to the STS, typically a projected ServiceAccount token (required).")
+	var allowedTokenURLs credentialplugin.StringList
+	flag.Var(&allowedTokenURLs, "allowed-token-url",
+		"STS endpoint that the subject token may be sent to (required). Repeat to allow several.")
+	flag.Parse()
+
+	credentialplugin.Run(Provider{
+		SubjectTokenFile: *subjectTokenFile,
+		AllowedTokenURLs: allowedTokenURLs,
+	})
+}
@@ -0,0 +1,186 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+)
+
+func TestTokenExchange(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "Token Exchange Plugin Suite")
+}
+
+// fakeSTS is a minimal RFC 8693 security token service.
+type fakeSTS struct {
+	server   *httptest.Server
+	forms    []url.Values
+	response map[string]any
+}
+
+func newFakeSTS() *fakeSTS {
+	sts := &fakeSTS{
+		response: map[string]any{
+			"access_token":      "exchanged-token",
+			"issued_token_type": tokenTypeAccessToken,
+			"token_type":        "Bearer",
+			"expires_in":        3600,
+		},
+	}
+	sts.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		sts.forms = append(sts.forms, r.PostForm)
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("subject_token") != "sa-token" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"error":             "invalid_grant",
+				"error_description": "subject token rejected",
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(sts.response)
+	}))
+	return sts
+}
+
+func execInfo(config string) clientauthenticationv1.ExecCredential {
+	return clientauthenticationv1.ExecCredential{
+		Spec: clientauthenticationv1.ExecCredentialSpec{
+			Cluster: &clientauthenticationv1.Cluster{
+				Server: "https://spoke.example.com",
+				Config: runtime.RawExtension{Raw: []byte(config)},
+			},
+		},
+	}
+}
+
+var _ = ginkgo.Describe("Provider", func() {
+	var (
+		sts *fakeSTS
+		p   Provider
+	)
+
+	ginkgo.BeforeEach(func() {
+		sts = newFakeSTS()
+		ginkgo.DeferCleanup(sts.server.Close)
+
+		tokenFile := filepath.Join(ginkgo.GinkgoT().TempDir(), "token")
+		gomega.Expect(os.WriteFile(tokenFile, []byte("sa-token\n"), 0o600)).To(gomega.Succeed())
+		p = Provider{
+			SubjectTokenFile: tokenFile,
+			AllowedTokenURLs: []string{sts.server.URL + "/token"},
+			HTTPClient:       sts.server.Client(),
+		}
+	})
+
+	ginkgo.It("should exchange the subject token and return the issued token with its expiry", func() {
+		start := time.Now()
+		status, err := p.GetToken(context.Background(), execInfo(`{
+			"tokenURL": "`+sts.server.URL+`/token",
+			"audience": "spoke-1",
+			"resource": "https://spoke.example.com",
+			"scopes": ["a", "b"]
+		}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(status.Token).To(gomega.Equal("exchanged-token"))
+		gomega.Expect(status.ExpirationTimestamp).NotTo(gomega.BeNil())
+		gomega.Expect(status.ExpirationTimestamp.Time).To(gomega.BeTemporally("~", start.Add(time.Hour-30*time.Second), 5*time.Second))
+
+		gomega.Expect(sts.forms).To(gomega.HaveLen(1))
+		form := sts.forms[0]
+		gomega.Expect(form.Get("grant_type")).To(gomega.Equal(grantTypeExchange))
+		gomega.Expect(form.Get("subject_token_type")).To(gomega.Equal(tokenTypeJWT))
+		gomega.Expect(form.Get("requested_token_type")).To(gomega.Equal(tokenTypeAccessToken))
+		gomega.Expect(form.Get("audience")).To(gomega.Equal("spoke-1"))
+		gomega.Expect(form.Get("resource")).To(gomega.Equal("https://spoke.example.com"))
+		gomega.Expect(form.Get("scope")).To(gomega.Equal("a b"))
+		gomega.Expect(form).NotTo(gomega.HaveKey("client_id"))
+	})
+
+	ginkgo.It("should fall back to the exp claim of a JWT without expires_in", func() {
+		exp := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+		payload, _ := json.Marshal(map[string]any{"exp": exp.Unix()})
+		sts.response = map[string]any{
+			"access_token":      "h." + base64.RawURLEncoding.EncodeToString(payload) + ".s",
+			"issued_token_type": tokenTypeJWT,
+			"token_type":        "N_A",
+		}
+
+		status, err := p.GetToken(context.Background(), execInfo(`{"tokenURL": "`+sts.server.URL+`/token"}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(status.ExpirationTimestamp).NotTo(gomega.BeNil())
+		gomega.Expect(status.ExpirationTimestamp.Time).To(gomega.BeTemporally("==", exp.Add(-30*time.Second)))
+	})
+
+	ginkgo.It("should re-read the subject token on every call", func() {
+		gomega.Expect(os.WriteFile(p.SubjectTokenFile, []byte("rotated"), 0o600)).To(gomega.Succeed())
+
+		_, err := p.GetToken(context.Background(), execInfo(`{"tokenURL": "`+sts.server.URL+`/token"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("invalid_grant: subject token rejected")))
+		gomega.Expect(sts.forms[0].Get("subject_token")).To(gomega.Equal("rotated"))
+	})
+
+	ginkgo.It("should refuse to send the subject token over plain http", func() {
+		_, err := p.GetToken(context.Background(), execInfo(`{"tokenURL": "http://sts.example.com/token"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("not an https URL")))
+	})
+
+	ginkgo.It("should only send the subject token to allowed endpoints", func() {
+		p.AllowedTokenURLs = []string{"https://sts.example.com/token"}
+
+		_, err := p.GetToken(context.Background(), execInfo(`{"tokenURL": "`+sts.server.URL+`/token"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("is not allowed")))
+		gomega.Expect(sts.forms).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should refuse every endpoint without an allowlist", func() {
+		p.AllowedTokenURLs = nil
+
+		_, err := p.GetToken(context.Background(), execInfo(`{"tokenURL": "`+sts.server.URL+`/token"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("--allowed-token-url")))
+		gomega.Expect(sts.forms).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should reject unsupported token types", func() {
+		sts.response["token_type"] = "DPoP"
+
+		_, err := p.GetToken(context.Background(), execInfo(`{"tokenURL": "`+sts.server.URL+`/token"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`unsupported token_type "DPoP"`)))
+	})
+
+	ginkgo.It("should require a subject token file", func() {
+		p.SubjectTokenFile = ""
+
+		_, err := p.GetToken(context.Background(), execInfo(`{"tokenURL": "`+sts.server.URL+`/token"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("--subject-token-file")))
+	})
+})
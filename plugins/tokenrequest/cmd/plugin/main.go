@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/client-go/kubernetes"
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/cluster-inventory-api/pkg/credentialplugin"
+)
+
+type Provider struct {
+	// KubeClient is the typed client for core Kubernetes resources (e.g. Secret).
+	KubeClient kubernetes.Interface
+	// Secrets, if set, is used to read Secrets instead of live requests through KubeClient.
+	Secrets credentialplugin.SecretReader
+	// Namespace, if set, overrides namespace inference.
+	Namespace string
+	// Namespaces restricts the namespaces a ClusterProfile may read the bootstrap Secret from.
+	Namespaces credentialplugin.NamespaceOptions
+}
+
+// NewDefault constructs a Provider with pre-initialized typed clientsets and an inferred namespace.
+func NewDefault() (*Provider, error) {
+	kubeClient, err := credentialplugin.NewKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	return &Provider{KubeClient: kubeClient, Namespace: credentialplugin.InferNamespace()}, nil
+}
+
+// ProviderName is the name of the credential provider.
+const ProviderName = "tokenrequest"
+
+// BootstrapTokenKey is the default `Secret.data` key of the bootstrap token.
+const BootstrapTokenKey = "token"
+
+// defaultExpirationSeconds is the requested token lifetime if none is configured.
+const defaultExpirationSeconds = 3600
+
+// bootstrapSecretRef references the hub Secret holding the bootstrap token.
+type bootstrapSecretRef struct {
+	Name      string `json:"name"`      // Secret name (required)
+	Namespace string `json:"namespace"` // Optional: namespace to read Secret from
+	Key       string `json:"key"`       // Optional: Secret.data key (defaults to BootstrapTokenKey)
+}
+
+// serviceAccountRef references the spoke ServiceAccount to mint tokens for.
+type serviceAccountRef struct {
+	Name      string `json:"name"`      // ServiceAccount name (required)
+	Namespace string `json:"namespace"` // ServiceAccount namespace (required)
+}
+
+type execClusterConfig struct {
+	BootstrapSecret   bootstrapSecretRef `json:"bootstrapSecret"`   // Hub Secret with a token allowed to create spoke ServiceAccount tokens
+	ServiceAccount    serviceAccountRef  `json:"serviceAccount"`    // Spoke ServiceAccount the returned token belongs to
+	Audiences         []string           `json:"audiences"`         // Optional: token audiences (defaults to the spoke API server)
+	ExpirationSeconds int64              `json:"expirationSeconds"` // Optional: requested lifetime (defaults to 3600)
+}
+
+func (Provider) Name() string { return ProviderName }
+
+// secrets returns the SecretReader used to read Secrets.
+func (p Provider) secrets() credentialplugin.SecretReader {
+	return credentialplugin.SecretReaderOrClient(p.Secrets, p.KubeClient)
+}
+
+func (p Provider) GetToken(
+	ctx context.Context,
+	info clientauthenticationv1.ExecCredential,
+) (clientauthenticationv1.ExecCredentialStatus, error) {
+	// Require pre-initialized typed clients
+	if p.KubeClient == nil && p.Secrets == nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, errors.New(
+			"provider clients are not initialized; construct with NewDefault or set clients",
+		)
+	}
+
+	// Validate presence of cluster config
+	if info.Spec.Cluster == nil || len(info.Spec.Cluster.Config.Raw) == 0 {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("missing ExecCredential.Spec.Cluster.Config")
+	}
+	var cfg execClusterConfig
+	if err := json.Unmarshal(info.Spec.Cluster.Config.Raw, &cfg); err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{},
+			fmt.Errorf("invalid ExecCredential.Spec.Cluster.Config: %w", err)
+	}
+	if cfg.BootstrapSecret.Name == "" {
+		return clientauthenticationv1.ExecCredentialStatus{},
+			fmt.Errorf("missing bootstrapSecret.name in ExecCredential.Spec.Cluster.Config")
+	}
+	if cfg.ServiceAccount.Name == "" || cfg.ServiceAccount.Namespace == "" {
+		return clientauthenticationv1.ExecCredentialStatus{},
+			fmt.Errorf("missing serviceAccount.name or serviceAccount.namespace in ExecCredential.Spec.Cluster.Config")
+	}
+	expirationSeconds := cfg.ExpirationSeconds
+	if expirationSeconds == 0 {
+		expirationSeconds = defaultExpirationSeconds
+	}
+
+	bootstrapToken, err := p.bootstrapToken(ctx, cfg.BootstrapSecret)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, err
+	}
+
+	// The bootstrap token is only ever sent to the spoke described by the ClusterProfile
+	spokeConfig, err := spokeRESTConfig(info.Spec.Cluster, bootstrapToken)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, err
+	}
+	spoke, err := kubernetes.NewForConfig(spokeConfig)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("failed to create spoke client: %w", err)
+	}
+
+	tr, err := spoke.CoreV1().ServiceAccounts(cfg.ServiceAccount.Namespace).CreateToken(ctx, cfg.ServiceAccount.Name,
+		&authenticationv1.TokenRequest{
+			Spec: authenticationv1.TokenRequestSpec{
+				Audiences:         cfg.Audiences,
+				ExpirationSeconds: &expirationSeconds,
+			},
+		}, metav1.CreateOptions{})
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
+			"failed to request token for serviceaccount %s/%s on %s: %w",
+			cfg.ServiceAccount.Namespace, cfg.ServiceAccount.Name, info.Spec.Cluster.Server, err,
+		)
+	}
+	if tr.Status.Token == "" {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf(
+			"token request for serviceaccount %s/%s returned no token",
+			cfg.ServiceAccount.Namespace, cfg.ServiceAccount.Name,
+		)
+	}
+
+	// The API server may shorten the requested lifetime; report the real expiry
+	return clientauthenticationv1.ExecCredentialStatus{
+		Token: tr.Status.Token,
+		ExpirationTimestamp: credentialplugin.ExpirationTimestamp(
+			tr.Status.ExpirationTimestamp.Time, credentialplugin.DefaultExpirationSkew,
+		),
+	}, nil
+}
+
+// bootstrapToken reads the bootstrap token from the referenced hub Secret.
+func (p Provider) bootstrapToken(ctx context.Context, ref bootstrapSecretRef) (string, error) {
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = p.Namespace
+	}
+	if err := p.Namespaces.Check(namespace, p.Namespace); err != nil {
+		return "", err
+	}
+	key := ref.Key
+	if key == "" {
+		key = BootstrapTokenKey
+	}
+	sec, err := p.secrets().GetSecret(ctx, namespace, ref.Name)
+	if err != nil {
+		return "", fmt.Errorf("failed to get secret %s/%s: %w", namespace, ref.Name, err)
+	}
+	data, ok := sec.Data[key]
+	if !ok || len(strings.TrimSpace(string(data))) == 0 {
+		return "", fmt.Errorf("secret %s/%s missing %q key", namespace, ref.Name, key)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// spokeRESTConfig returns the client config for the spoke cluster described by
+// ExecCredential.Spec.Cluster, authenticated with token.
+func spokeRESTConfig(cluster *clientauthenticationv1.Cluster, token string) (*rest.Config, error) {
+	cfg := &rest.Config{
+		Host:        cluster.Server,
+		BearerToken: token,
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData:     cluster.CertificateAuthorityData,
+			ServerName: cluster.TLSServerName,
+			Insecure:   cluster.InsecureSkipTLSVerify,
+		},
+		DisableCompression: cluster.DisableCompression,
+		UserAgent:          ProviderName,
+		Timeout:            credentialplugin.HTTPTimeout,
+	}
+	if cluster.ProxyURL != "" {
+		proxy, err := url.Parse(cluster.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy-url %q: %w", cluster.ProxyURL, err)
+		}
+		cfg.Proxy = http.ProxyURL(proxy)
+	}
+	return cfg, nil
+}
+
+func main() {
+	var namespaceOpts credentialplugin.NamespaceOptions
+	namespaceOpts.AddFlags(flag.CommandLine)
+	flag.Parse()
+
+	p, err := NewDefault()
+	if err != nil {
+		panic(err)
+	}
+	p.Namespaces = namespaceOpts
+	credentialplugin.Run(*p)
+}
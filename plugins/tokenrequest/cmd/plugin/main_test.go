@@ -0,0 +1,207 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+)
+
+func TestTokenRequest(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "TokenRequest Plugin Suite")
+}
+
+// fakeSpoke serves the ServiceAccount TokenRequest API of a spoke cluster.
+type fakeSpoke struct {
+	server   *httptest.Server
+	requests []authenticationv1.TokenRequest
+	paths    []string
+	expiry   time.Time
+}
+
+func newFakeSpoke() *fakeSpoke {
+	s := &fakeSpoke{expiry: time.Now().Add(time.Hour).Truncate(time.Second)}
+	s.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer bootstrap-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(metav1.Status{
+				TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+				Status:   metav1.StatusFailure, Reason: metav1.StatusReasonUnauthorized, Code: http.StatusUnauthorized,
+			})
+			return
+		}
+		// client-go may send protobuf, so decode with the client scheme
+		body, _ := io.ReadAll(r.Body)
+		obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(body, nil, nil)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		tr := *obj.(*authenticationv1.TokenRequest)
+		s.requests = append(s.requests, tr)
+		s.paths = append(s.paths, r.Method+" "+r.URL.Path)
+		tr.APIVersion, tr.Kind = "authentication.k8s.io/v1", "TokenRequest"
+		tr.Status = authenticationv1.TokenRequestStatus{
+			Token:               "minted-token",
+			ExpirationTimestamp: metav1.NewTime(s.expiry),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(tr)
+	}))
+	return s
+}
+
+func (s *fakeSpoke) execInfo(config string) clientauthenticationv1.ExecCredential {
+	return clientauthenticationv1.ExecCredential{
+		Spec: clientauthenticationv1.ExecCredentialSpec{
+			Cluster: &clientauthenticationv1.Cluster{
+				Server:                   s.server.URL,
+				CertificateAuthorityData: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.server.Certificate().Raw}),
+				Config:                   runtime.RawExtension{Raw: []byte(config)},
+			},
+		},
+	}
+}
+
+var _ = ginkgo.Describe("Provider", func() {
+	var (
+		spoke *fakeSpoke
+		p     Provider
+	)
+
+	ginkgo.BeforeEach(func() {
+		spoke = newFakeSpoke()
+		ginkgo.DeferCleanup(spoke.server.Close)
+		p = Provider{
+			KubeClient: fake.NewClientset(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "spoke-1-bootstrap", Namespace: "default"},
+				Data:       map[string][]byte{"token": []byte("bootstrap-token\n")},
+			}),
+			Namespace: "default",
+		}
+	})
+
+	ginkgo.It("should mint a token for the configured ServiceAccount and return its real expiry", func() {
+		status, err := p.GetToken(context.Background(), spoke.execInfo(`{
+			"bootstrapSecret": {"name": "spoke-1-bootstrap"},
+			"serviceAccount": {"name": "fleet", "namespace": "fleet-system"},
+			"audiences": ["https://spoke.example.com"],
+			"expirationSeconds": 7200
+		}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(status.Token).To(gomega.Equal("minted-token"))
+		gomega.Expect(status.ExpirationTimestamp).NotTo(gomega.BeNil())
+		gomega.Expect(status.ExpirationTimestamp.Time).To(gomega.BeTemporally("==", spoke.expiry.Add(-30*time.Second)))
+
+		gomega.Expect(spoke.paths).To(gomega.Equal([]string{
+			"POST /api/v1/namespaces/fleet-system/serviceaccounts/fleet/token",
+		}))
+		gomega.Expect(spoke.requests[0].Spec.Audiences).To(gomega.Equal([]string{"https://spoke.example.com"}))
+		gomega.Expect(*spoke.requests[0].Spec.ExpirationSeconds).To(gomega.Equal(int64(7200)))
+	})
+
+	ginkgo.It("should request one hour by default", func() {
+		_, err := p.GetToken(context.Background(), spoke.execInfo(`{
+			"bootstrapSecret": {"name": "spoke-1-bootstrap"},
+			"serviceAccount": {"name": "fleet", "namespace": "fleet-system"}
+		}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(*spoke.requests[0].Spec.ExpirationSeconds).To(gomega.Equal(int64(3600)))
+		gomega.Expect(spoke.requests[0].Spec.Audiences).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should verify the spoke with the ClusterProfile CA", func() {
+		info := spoke.execInfo(`{
+			"bootstrapSecret": {"name": "spoke-1-bootstrap"},
+			"serviceAccount": {"name": "fleet", "namespace": "fleet-system"}
+		}`)
+		info.Spec.Cluster.CertificateAuthorityData = nil
+
+		_, err := p.GetToken(context.Background(), info)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("certificate")))
+		gomega.Expect(spoke.requests).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should report a rejected bootstrap token", func() {
+		p.KubeClient = fake.NewClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "spoke-1-bootstrap", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("revoked")},
+		})
+
+		_, err := p.GetToken(context.Background(), spoke.execInfo(`{
+			"bootstrapSecret": {"name": "spoke-1-bootstrap"},
+			"serviceAccount": {"name": "fleet", "namespace": "fleet-system"}
+		}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("failed to request token for serviceaccount fleet-system/fleet")))
+	})
+
+	ginkgo.It("should require the ServiceAccount namespace", func() {
+		_, err := p.GetToken(context.Background(), spoke.execInfo(`{
+			"bootstrapSecret": {"name": "spoke-1-bootstrap"},
+			"serviceAccount": {"name": "fleet"}
+		}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("serviceAccount.namespace")))
+	})
+
+	ginkgo.It("should report a missing bootstrap Secret", func() {
+		_, err := p.GetToken(context.Background(), spoke.execInfo(`{
+			"bootstrapSecret": {"name": "missing"},
+			"serviceAccount": {"name": "fleet", "namespace": "fleet-system"}
+		}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("failed to get secret default/missing")))
+	})
+
+	ginkgo.It("should only read the bootstrap Secret in the allowed namespaces", func() {
+		client := fake.NewClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "spoke-1-bootstrap", Namespace: "kube-system"},
+			Data:       map[string][]byte{"token": []byte("bootstrap-token")},
+		})
+		p.KubeClient = client
+		info := spoke.execInfo(`{
+			"bootstrapSecret": {"name": "spoke-1-bootstrap", "namespace": "kube-system"},
+			"serviceAccount": {"name": "fleet", "namespace": "fleet-system"}
+		}`)
+
+		_, err := p.GetToken(context.Background(), info)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`namespace "kube-system" is not allowed`)))
+		gomega.Expect(client.Actions()).To(gomega.BeEmpty())
+		gomega.Expect(spoke.requests).To(gomega.BeEmpty())
+
+		p.Namespaces.AllowedNamespaces = []string{"kube-system"}
+		status, err := p.GetToken(context.Background(), info)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(status.Token).To(gomega.Equal("minted-token"))
+	})
+})
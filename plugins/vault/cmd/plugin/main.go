@@ -0,0 +1,357 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/cluster-inventory-api/pkg/credentialplugin"
+)
+
+// ProviderName is the name of the credential provider.
+const ProviderName = "vault"
+
+// Keys of the KV v2 secret data holding the credentials.
+const (
+	DataKubeconfigKey = "kubeconfig"
+	DataTokenKey      = "token"
+)
+
+const (
+	defaultAuthMount               = "kubernetes"
+	defaultKVMount                 = "secret"
+	defaultPathTemplate            = "{{.clusterName}}"
+	defaultServiceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	// maxResponseSize bounds the size of Vault responses.
+	maxResponseSize = 1 << 20
+)
+
+type Provider struct {
+	// Address is the Vault address, e.g. https://vault.example.com:8200.
+	Address string
+	// Namespace is the Vault Enterprise namespace, if any.
+	Namespace string
+	// AuthMount is the mount path of the Kubernetes auth method.
+	AuthMount string
+	// Role is the Vault role to log in as.
+	Role string
+	// ServiceAccountTokenFile is the token presented to the Kubernetes auth method.
+	ServiceAccountTokenFile string
+	// KVMount is the mount path of the KV v2 secrets engine.
+	KVMount string
+	// PathTemplate is a text/template rendered with the fields of
+	// ExecCredential.Spec.Cluster.Config to get the secret path below KVMount.
+	PathTemplate *template.Template
+	// Kubeconfig controls how credentials are extracted from a kubeconfig.
+	// Kubeconfig.Context is overridden by the context in ExecCredential.Spec.Cluster.Config.
+	Kubeconfig credentialplugin.KubeconfigOptions
+	// HTTPClient is used for Vault requests. Defaults to a client with credentialplugin.HTTPTimeout.
+	HTTPClient *http.Client
+}
+
+type execClusterConfig struct {
+	Context string `json:"context"` // Optional: kubeconfig context name
+	// All other fields, such as clusterName, are template variables of the path.
+}
+
+// kvV2Response is the response of a KV v2 read.
+type kvV2Response struct {
+	LeaseDuration int64 `json:"lease_duration"`
+	Data          struct {
+		Data map[string]any `json:"data"`
+	} `json:"data"`
+}
+
+// loginResponse is the response of an auth method login.
+type loginResponse struct {
+	Auth struct {
+		ClientToken string `json:"client_token"`
+	} `json:"auth"`
+}
+
+func (Provider) Name() string { return ProviderName }
+
+func (p Provider) GetToken(
+	ctx context.Context,
+	info clientauthenticationv1.ExecCredential,
+) (clientauthenticationv1.ExecCredentialStatus, error) {
+	if p.Address == "" || p.Role == "" {
+		return clientauthenticationv1.ExecCredentialStatus{}, errors.New("vault address and role must be configured")
+	}
+
+	// Validate presence of cluster config
+	if info.Spec.Cluster == nil || len(info.Spec.Cluster.Config.Raw) == 0 {
+		return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("missing ExecCredential.Spec.Cluster.Config")
+	}
+	var cfg execClusterConfig
+	if err := json.Unmarshal(info.Spec.Cluster.Config.Raw, &cfg); err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{},
+			fmt.Errorf("invalid ExecCredential.Spec.Cluster.Config: %w", err)
+	}
+	var vars map[string]any
+	if err := json.Unmarshal(info.Spec.Cluster.Config.Raw, &vars); err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{},
+			fmt.Errorf("invalid ExecCredential.Spec.Cluster.Config: %w", err)
+	}
+	path, err := p.secretPath(vars)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, err
+	}
+
+	vaultToken, err := p.login(ctx)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, err
+	}
+	defer p.revoke(vaultToken)
+
+	start := time.Now()
+	secret, err := p.readKV(ctx, vaultToken, path)
+	if err != nil {
+		return clientauthenticationv1.ExecCredentialStatus{}, err
+	}
+
+	var status clientauthenticationv1.ExecCredentialStatus
+	switch {
+	case secret.Data.Data[DataKubeconfigKey] != nil:
+		raw, ok := secret.Data.Data[DataKubeconfigKey].(string)
+		if !ok {
+			return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("vault secret %s: %q is not a string", path, DataKubeconfigKey)
+		}
+		config, err := clientcmd.Load([]byte(raw))
+		if err != nil {
+			return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("failed to parse kubeconfig from vault secret %s: %w", path, err)
+		}
+		opts := p.Kubeconfig
+		opts.Context = cfg.Context
+		status, err = credentialplugin.KubeconfigCredentials(ctx, config, info.Spec.Cluster, opts)
+		if err != nil {
+			return clientauthenticationv1.ExecCredentialStatus{}, err
+		}
+	case secret.Data.Data[DataTokenKey] != nil:
+		token, ok := secret.Data.Data[DataTokenKey].(string)
+		if !ok || strings.TrimSpace(token) == "" {
+			return clientauthenticationv1.ExecCredentialStatus{}, fmt.Errorf("vault secret %s: %q is not a non-empty string", path, DataTokenKey)
+		}
+		status.Token = strings.TrimSpace(token)
+		if exp, err := credentialplugin.JWTExpiry(status.Token); err == nil {
+			status.ExpirationTimestamp = credentialplugin.ExpirationTimestamp(exp, credentialplugin.DefaultExpirationSkew)
+		}
+	default:
+		return clientauthenticationv1.ExecCredentialStatus{},
+			fmt.Errorf("vault secret %s has neither %q nor %q", path, DataKubeconfigKey, DataTokenKey)
+	}
+
+	// A lease on the secret bounds how long the credentials may be used
+	if secret.LeaseDuration > 0 {
+		leaseExpiry := start.Add(time.Duration(secret.LeaseDuration) * time.Second)
+		if status.ExpirationTimestamp == nil || leaseExpiry.Before(status.ExpirationTimestamp.Time) {
+			status.ExpirationTimestamp = credentialplugin.ExpirationTimestamp(leaseExpiry, credentialplugin.DefaultExpirationSkew)
+		}
+	}
+	return status, nil
+}
+
+// secretPath renders the path template with the given variables. Each
+// variable must be a single path segment, so that a ClusterProfile cannot
+// reach paths outside of the shape chosen by the consumer.
+func (p Provider) secretPath(vars map[string]any) (string, error) {
+	for k, v := range vars {
+		s, ok := v.(string)
+		if !ok || k == "context" {
+			continue
+		}
+		if s == "" || s == "." || s == ".." || strings.ContainsAny(s, "/\\?#%") {
+			return "", fmt.Errorf("invalid value %q for %q in ExecCredential.Spec.Cluster.Config", s, k)
+		}
+	}
+	tmpl := p.PathTemplate
+	if tmpl == nil {
+		tmpl = template.Must(template.New("path").Option("missingkey=error").Parse(defaultPathTemplate))
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", fmt.Errorf("failed to render vault path: %w", err)
+	}
+	path := strings.Trim(buf.String(), "/")
+	if path == "" {
+		return "", fmt.Errorf("vault path template rendered an empty path")
+	}
+	return path, nil
+}
+
+// login authenticates with the Kubernetes auth method and returns a Vault token.
+func (p Provider) login(ctx context.Context) (string, error) {
+	tokenFile := p.ServiceAccountTokenFile
+	if tokenFile == "" {
+		tokenFile = defaultServiceAccountTokenFile
+	}
+	jwt, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read service account token: %w", err)
+	}
+	body, err := json.Marshal(map[string]string{"role": p.Role, "jwt": strings.TrimSpace(string(jwt))})
+	if err != nil {
+		return "", err
+	}
+	mount := p.AuthMount
+	if mount == "" {
+		mount = defaultAuthMount
+	}
+
+	var resp loginResponse
+	if err := p.do(ctx, http.MethodPost, "auth/"+strings.Trim(mount, "/")+"/login", "", body, &resp); err != nil {
+		return "", fmt.Errorf("vault login with role %q failed: %w", p.Role, err)
+	}
+	if resp.Auth.ClientToken == "" {
+		return "", fmt.Errorf("vault login with role %q returned no client token", p.Role)
+	}
+	return resp.Auth.ClientToken, nil
+}
+
+// readKV reads the latest version of a KV v2 secret.
+func (p Provider) readKV(ctx context.Context, vaultToken, path string) (*kvV2Response, error) {
+	mount := p.KVMount
+	if mount == "" {
+		mount = defaultKVMount
+	}
+	var resp kvV2Response
+	if err := p.do(ctx, http.MethodGet, strings.Trim(mount, "/")+"/data/"+path, vaultToken, nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed to read vault secret %s: %w", path, err)
+	}
+	return &resp, nil
+}
+
+// revoke revokes the Vault token obtained by login. Failures are ignored
+// because the token expires with its TTL anyway.
+func (p Provider) revoke(vaultToken string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = p.do(ctx, http.MethodPost, "auth/token/revoke-self", vaultToken, nil, nil)
+}
+
+// do sends a request to the Vault HTTP API and decodes the JSON response into out.
+func (p Provider) do(ctx context.Context, method, path, vaultToken string, body []byte, out any) error {
+	u, err := url.JoinPath(p.Address, "v1", path)
+	if err != nil {
+		return fmt.Errorf("invalid vault address: %w", err)
+	}
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, r)
+	if err != nil {
+		return err
+	}
+	if vaultToken != "" {
+		req.Header.Set("X-Vault-Token", vaultToken)
+	}
+	if p.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.Namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := p.HTTPClient
+	if client == nil {
+		client = credentialplugin.NewHTTPClient(nil)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var e struct {
+			Errors []string `json:"errors"`
+		}
+		if json.Unmarshal(data, &e) == nil && len(e.Errors) > 0 {
+			return fmt.Errorf("%s: %s", resp.Status, strings.Join(e.Errors, "; "))
+		}
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// newHTTPClient returns an HTTP client trusting the CA bundle in caFile, or
+// the system roots if caFile is empty.
+func newHTTPClient(caFile string) (*http.Client, error) {
+	if caFile == "" {
+		return credentialplugin.NewHTTPClient(nil), nil
+	}
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vault CA certificate: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", caFile)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	return credentialplugin.NewHTTPClient(transport), nil
+}
+
+func main() {
+	address := flag.String("vault-addr", os.Getenv("VAULT_ADDR"), "Vault address. Defaults to $VAULT_ADDR.")
+	namespace := flag.String("vault-namespace", os.Getenv("VAULT_NAMESPACE"),
+		"Vault Enterprise namespace. Defaults to $VAULT_NAMESPACE.")
+	caCert := flag.String("vault-ca-cert", os.Getenv("VAULT_CACERT"),
+		"CA bundle used to verify Vault. Defaults to $VAULT_CACERT, then the system roots.")
+	authMount := flag.String("auth-mount", defaultAuthMount, "Mount path of the Vault Kubernetes auth method.")
+	role := flag.String("role", "", "Vault role to log in as (required).")
+	saToken := flag.String("service-account-token-file", defaultServiceAccountTokenFile,
+		"ServiceAccount token presented to the Vault Kubernetes auth method.")
+	kvMount := flag.String("kv-mount", defaultKVMount, "Mount path of the KV v2 secrets engine.")
+	pathTemplate := flag.String("path-template", defaultPathTemplate,
+		"Go template of the secret path below --kv-mount, rendered with the fields of the exec extension.")
+	var kubeconfigOpts credentialplugin.KubeconfigOptions
+	kubeconfigOpts.AddFlags(flag.CommandLine)
+	flag.Parse()
+
+	tmpl, err := template.New("path").Option("missingkey=error").Parse(*pathTemplate)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[%s] invalid --path-template: %v\n", ProviderName, err)
+		os.Exit(1)
+	}
+	httpClient, err := newHTTPClient(*caCert)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[%s] %v\n", ProviderName, err)
+		os.Exit(1)
+	}
+
+	credentialplugin.Run(Provider{
+		Address:                 *address,
+		Namespace:               *namespace,
+		AuthMount:               *authMount,
+		Role:                    *role,
+		ServiceAccountTokenFile: *saToken,
+		KVMount:                 *kvMount,
+		PathTemplate:            tmpl,
+		Kubeconfig:              kubeconfigOpts,
+		HTTPClient:              httpClient,
+	})
+}
@@ -0,0 +1,215 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+	"sigs.k8s.io/cluster-inventory-api/pkg/credentialplugin"
+)
+
+func TestVault(t *testing.T) {
+	gomega.RegisterFailHandler(ginkgo.Fail)
+	ginkgo.RunSpecs(t, "Vault Plugin Suite")
+}
+
+// fakeVault serves the Kubernetes auth login, KV v2 reads and token revocation.
+type fakeVault struct {
+	server  *httptest.Server
+	secrets map[string]map[string]any // KV v2 path -> data
+	lease   int64
+	reads   []string
+	revoked []string
+}
+
+func newFakeVault() *fakeVault {
+	v := &fakeVault{secrets: map[string]map[string]any{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/auth/kubernetes/login", func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req["role"] != "hub" || req["jwt"] != "sa-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(map[string]any{"errors": []string{"permission denied"}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"auth": map[string]any{"client_token": "vault-token"}})
+	})
+	mux.HandleFunc("GET /v1/secret/data/{path...}", func(w http.ResponseWriter, r *http.Request) {
+		path := r.PathValue("path")
+		v.reads = append(v.reads, path)
+		data, ok := v.secrets[path]
+		if r.Header.Get("X-Vault-Token") != "vault-token" || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]any{"errors": []string{}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"lease_duration": v.lease,
+			"data":           map[string]any{"data": data, "metadata": map[string]any{"version": 1}},
+		})
+	})
+	mux.HandleFunc("POST /v1/auth/token/revoke-self", func(w http.ResponseWriter, r *http.Request) {
+		v.revoked = append(v.revoked, r.Header.Get("X-Vault-Token"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	v.server = httptest.NewTLSServer(mux)
+	return v
+}
+
+func execInfo(config string) clientauthenticationv1.ExecCredential {
+	return clientauthenticationv1.ExecCredential{
+		Spec: clientauthenticationv1.ExecCredentialSpec{
+			Cluster: &clientauthenticationv1.Cluster{
+				Server: "https://spoke.example.com",
+				Config: runtime.RawExtension{Raw: []byte(config)},
+			},
+		},
+	}
+}
+
+var _ = ginkgo.Describe("Provider", func() {
+	var (
+		vault *fakeVault
+		p     Provider
+	)
+
+	ginkgo.BeforeEach(func() {
+		vault = newFakeVault()
+		ginkgo.DeferCleanup(vault.server.Close)
+
+		tokenFile := filepath.Join(ginkgo.GinkgoT().TempDir(), "token")
+		gomega.Expect(os.WriteFile(tokenFile, []byte("sa-token\n"), 0o600)).To(gomega.Succeed())
+		p = Provider{
+			Address:                 vault.server.URL,
+			Role:                    "hub",
+			ServiceAccountTokenFile: tokenFile,
+			PathTemplate:            template.Must(template.New("path").Option("missingkey=error").Parse("clusters/{{.clusterName}}")),
+			HTTPClient:              vault.server.Client(),
+		}
+	})
+
+	ginkgo.It("should read a token and report the lease as expiry", func() {
+		vault.secrets["clusters/spoke-1"] = map[string]any{"token": "spoke-token"}
+		vault.lease = 600
+
+		start := time.Now()
+		status, err := p.GetToken(context.Background(), execInfo(`{"clusterName": "spoke-1"}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(status.Token).To(gomega.Equal("spoke-token"))
+		gomega.Expect(status.ExpirationTimestamp).NotTo(gomega.BeNil())
+		gomega.Expect(status.ExpirationTimestamp.Time).To(gomega.BeTemporally("~", start.Add(10*time.Minute-30*time.Second), 5*time.Second))
+		gomega.Expect(vault.revoked).To(gomega.Equal([]string{"vault-token"}))
+	})
+
+	ginkgo.It("should not set an expiry without a lease", func() {
+		vault.secrets["clusters/spoke-1"] = map[string]any{"token": "spoke-token"}
+
+		status, err := p.GetToken(context.Background(), execInfo(`{"clusterName": "spoke-1"}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(status.ExpirationTimestamp).To(gomega.BeNil())
+	})
+
+	ginkgo.It("should extract credentials from a kubeconfig", func() {
+		vault.secrets["clusters/spoke-1"] = map[string]any{"kubeconfig": `apiVersion: v1
+kind: Config
+clusters:
+- name: spoke
+  cluster:
+    server: https://spoke.example.com
+contexts:
+- name: spoke
+  context:
+    cluster: spoke
+    user: admin
+current-context: spoke
+users:
+- name: admin
+  user:
+    token: kubeconfig-token
+`}
+
+		status, err := p.GetToken(context.Background(), execInfo(`{"clusterName": "spoke-1"}`))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(status.Token).To(gomega.Equal("kubeconfig-token"))
+	})
+
+	ginkgo.It("should apply the kubeconfig cluster check", func() {
+		vault.secrets["clusters/spoke-1"] = map[string]any{"kubeconfig": `apiVersion: v1
+kind: Config
+clusters:
+- name: other
+  cluster:
+    server: https://other.example.com
+contexts:
+- name: other
+  context:
+    cluster: other
+    user: admin
+current-context: other
+users:
+- name: admin
+  user:
+    token: kubeconfig-token
+`}
+
+		_, err := p.GetToken(context.Background(), execInfo(`{"clusterName": "spoke-1"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("does not match ClusterProfile server")))
+	})
+
+	ginkgo.It("should reject template variables that are not a single path segment", func() {
+		_, err := p.GetToken(context.Background(), execInfo(`{"clusterName": "../admin"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("invalid value")))
+		gomega.Expect(vault.reads).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("should fail on missing template variables", func() {
+		_, err := p.GetToken(context.Background(), execInfo(`{"name": "spoke-1"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("clusterName")))
+	})
+
+	ginkgo.It("should report login errors", func() {
+		p.Role = "other"
+
+		_, err := p.GetToken(context.Background(), execInfo(`{"clusterName": "spoke-1"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("permission denied")))
+	})
+
+	ginkgo.It("should report missing secrets", func() {
+		_, err := p.GetToken(context.Background(), execInfo(`{"clusterName": "spoke-2"}`))
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("failed to read vault secret clusters/spoke-2")))
+		gomega.Expect(strings.Join(vault.revoked, ",")).To(gomega.Equal("vault-token"))
+	})
+	ginkgo.It("should bound requests to Vault with a timeout", func() {
+		client, err := newHTTPClient("")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(client.Timeout).To(gomega.Equal(credentialplugin.HTTPTimeout))
+	})
+})
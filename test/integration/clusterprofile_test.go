@@ -23,11 +23,16 @@ import (
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/utils/ptr"
 
 	cpv1alpha1 "sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+	cpv1alpha1ac "sigs.k8s.io/cluster-inventory-api/client/applyconfiguration/apis/v1alpha1"
 )
 
 var _ = ginkgo.Describe("ClusterProfileAPI test", func() {
@@ -99,4 +104,119 @@ var _ = ginkgo.Describe("ClusterProfileAPI test", func() {
 		)
 		gomega.Expect(err).ToNot(gomega.HaveOccurred())
 	})
+	ginkgo.It("Should store the resource summary in the ClusterProfile status", func() {
+		clusterProfile := &cpv1alpha1.ClusterProfile{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   clusterName,
+				Labels: map[string]string{cpv1alpha1.LabelClusterManagerKey: clusterManagerName},
+			},
+			Spec: cpv1alpha1.ClusterProfileSpec{
+				DisplayName: clusterName,
+				ClusterManager: cpv1alpha1.ClusterManager{
+					Name: clusterManagerName,
+				},
+			},
+		}
+
+		clusterProfile, err := clusterProfileClient.ApisV1alpha1().ClusterProfiles(testNamespace).Create(
+			context.TODO(),
+			clusterProfile,
+			metav1.CreateOptions{},
+		)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+		summary := &cpv1alpha1.ResourceSummary{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("8"),
+				corev1.ResourceMemory: resource.MustParse("32Gi"),
+			},
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("7500m"),
+				corev1.ResourceMemory: resource.MustParse("30Gi"),
+			},
+			NodeCount:        ptr.To[int32](2),
+			LastObservedTime: metav1.Now(),
+		}
+		newClusterProfile := clusterProfile.DeepCopy()
+		newClusterProfile.Status.Resources = summary
+
+		updated, err := clusterProfileClient.ApisV1alpha1().ClusterProfiles(testNamespace).UpdateStatus(
+			context.TODO(),
+			newClusterProfile,
+			metav1.UpdateOptions{},
+		)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(updated.Status.Resources.Equal(summary)).To(gomega.BeTrue())
+
+		newClusterProfile = updated.DeepCopy()
+		newClusterProfile.Status.Resources.NodeCount = ptr.To[int32](-1)
+		_, err = clusterProfileClient.ApisV1alpha1().ClusterProfiles(testNamespace).UpdateStatus(
+			context.TODO(),
+			newClusterProfile,
+			metav1.UpdateOptions{},
+		)
+		gomega.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	ginkgo.It("Should merge properties applied by different field managers", func() {
+		profile := cpv1alpha1ac.ClusterProfile(clusterName, testNamespace).
+			WithLabels(map[string]string{cpv1alpha1.LabelClusterManagerKey: clusterManagerName}).
+			WithSpec(cpv1alpha1ac.ClusterProfileSpec().
+				WithClusterManager(cpv1alpha1ac.ClusterManager().WithName(clusterManagerName)))
+		_, err := clusterProfileClient.ApisV1alpha1().ClusterProfiles(testNamespace).Apply(
+			context.TODO(), profile, metav1.ApplyOptions{FieldManager: clusterManagerName},
+		)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+		applyProperty := func(manager, name, value string) {
+			status := cpv1alpha1ac.ClusterProfile(clusterName, testNamespace).
+				WithStatus(cpv1alpha1ac.ClusterProfileStatus().
+					WithProperties(cpv1alpha1ac.Property().WithName(name).WithValue(value)))
+			_, err := clusterProfileClient.ApisV1alpha1().ClusterProfiles(testNamespace).ApplyStatus(
+				context.TODO(), status, metav1.ApplyOptions{FieldManager: manager},
+			)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		}
+		applyProperty("region-agent", "region", "us-east-1")
+		applyProperty("version-agent", "version", "1.35")
+		applyProperty("region-agent", "region", "us-west-2")
+
+		got, err := clusterProfileClient.ApisV1alpha1().ClusterProfiles(testNamespace).Get(
+			context.TODO(), clusterName, metav1.GetOptions{},
+		)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		values := map[string]string{}
+		for _, p := range got.Status.Properties {
+			values[p.Name] = p.Value
+		}
+		gomega.Expect(values).To(gomega.Equal(map[string]string{"region": "us-west-2", "version": "1.35"}))
+	})
+
+	ginkgo.It("Should select ClusterProfiles by cluster manager name", func() {
+		for _, manager := range []string{clusterManagerName, "other-manager"} {
+			_, err := clusterProfileClient.ApisV1alpha1().ClusterProfiles(testNamespace).Create(
+				context.TODO(),
+				&cpv1alpha1.ClusterProfile{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   fmt.Sprintf("%s-%s", clusterName, manager),
+						Labels: map[string]string{cpv1alpha1.LabelClusterManagerKey: manager},
+					},
+					Spec: cpv1alpha1.ClusterProfileSpec{
+						ClusterManager: cpv1alpha1.ClusterManager{Name: manager},
+					},
+				},
+				metav1.CreateOptions{},
+			)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		}
+
+		list, err := clusterProfileClient.ApisV1alpha1().ClusterProfiles(testNamespace).List(
+			context.TODO(),
+			metav1.ListOptions{FieldSelector: fields.OneTermEqualSelector(
+				cpv1alpha1.ClusterProfileClusterManagerNameField, clusterManagerName).String()},
+		)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(list.Items).To(gomega.HaveLen(1))
+		gomega.Expect(list.Items[0].Spec.ClusterManager.Name).To(gomega.Equal(clusterManagerName))
+	})
 })
@@ -0,0 +1,168 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+
+	cpv1alpha1 "sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+)
+
+var _ = ginkgo.Describe("ClusterProfile consistency rules", func() {
+	var clusterName string
+
+	ginkgo.BeforeEach(func() {
+		clusterName = fmt.Sprintf("cluster-%s", rand.String(5))
+	})
+
+	newClusterProfile := func(labels map[string]string) *cpv1alpha1.ClusterProfile {
+		return &cpv1alpha1.ClusterProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterName, Labels: labels},
+			Spec: cpv1alpha1.ClusterProfileSpec{
+				ClusterManager: cpv1alpha1.ClusterManager{Name: "fleet"},
+			},
+		}
+	}
+
+	create := func(profile *cpv1alpha1.ClusterProfile) (*cpv1alpha1.ClusterProfile, error) {
+		return clusterProfileClient.ApisV1alpha1().ClusterProfiles(testNamespace).Create(
+			context.TODO(), profile, metav1.CreateOptions{},
+		)
+	}
+
+	ginkgo.It("Should require the cluster manager label", func() {
+		// The policy becomes effective once the API server has observed it.
+		gomega.Eventually(func() error {
+			_, err := create(newClusterProfile(nil))
+			return err
+		}).Should(gomega.Satisfy(errors.IsInvalid))
+
+		_, err := create(newClusterProfile(map[string]string{"other": "label"}))
+		gomega.Expect(errors.IsInvalid(err)).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("Should not allow removing the cluster manager label", func() {
+		profile, err := create(newClusterProfile(map[string]string{cpv1alpha1.LabelClusterManagerKey: "fleet"}))
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+		gomega.Eventually(func() error {
+			updated := profile.DeepCopy()
+			delete(updated.Labels, cpv1alpha1.LabelClusterManagerKey)
+			_, err := clusterProfileClient.ApisV1alpha1().ClusterProfiles(testNamespace).Update(
+				context.TODO(), updated, metav1.UpdateOptions{},
+			)
+			return err
+		}).Should(gomega.Satisfy(errors.IsInvalid))
+	})
+
+	ginkgo.It("Should allow updating a ClusterProfile created without the label", func() {
+		gomega.Eventually(func() error {
+			_, err := create(newClusterProfile(nil))
+			return err
+		}).Should(gomega.Satisfy(errors.IsInvalid))
+
+		client := clusterProfileClient.ApisV1alpha1().ClusterProfiles(testNamespace)
+		gomega.Expect(client.Delete(context.TODO(), legacyClusterProfile.Name, metav1.DeleteOptions{})).To(gomega.Succeed())
+		profile, err := client.Get(context.TODO(), legacyClusterProfile.Name, metav1.GetOptions{})
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+		// Setting a wrong label is still a label change and is checked.
+		mislabeled := profile.DeepCopy()
+		mislabeled.Labels = map[string]string{cpv1alpha1.LabelClusterManagerKey: "other"}
+		_, err = client.Update(context.TODO(), mislabeled, metav1.UpdateOptions{})
+		gomega.Expect(errors.IsInvalid(err)).To(gomega.BeTrue())
+
+		profile.Finalizers = nil
+		_, err = client.Update(context.TODO(), profile, metav1.UpdateOptions{})
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		_, err = client.Get(context.TODO(), legacyClusterProfile.Name, metav1.GetOptions{})
+		gomega.Expect(errors.IsNotFound(err)).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("Should reject a provider listed in both provider lists", func() {
+		profile, err := create(newClusterProfile(map[string]string{cpv1alpha1.LabelClusterManagerKey: "fleet"}))
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+		profile.Status.AccessProviders = []cpv1alpha1.AccessProvider{{Name: "secretreader"}}
+		profile.Status.CredentialProviders = []cpv1alpha1.AccessProvider{{Name: "legacy"}, {Name: "secretreader"}}
+		_, err = clusterProfileClient.ApisV1alpha1().ClusterProfiles(testNamespace).UpdateStatus(
+			context.TODO(), profile, metav1.UpdateOptions{},
+		)
+		gomega.Expect(errors.IsInvalid(err)).To(gomega.BeTrue())
+		gomega.Expect(err.Error()).To(gomega.ContainSubstring("must not be listed in both"))
+
+		profile.Status.CredentialProviders = profile.Status.CredentialProviders[:1]
+		_, err = clusterProfileClient.ApisV1alpha1().ClusterProfiles(testNamespace).UpdateStatus(
+			context.TODO(), profile, metav1.UpdateOptions{},
+		)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	})
+
+	ginkgo.It("Should reject observation times in the future", func() {
+		profile, err := create(newClusterProfile(map[string]string{cpv1alpha1.LabelClusterManagerKey: "fleet"}))
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+		profile.Status.Properties = []cpv1alpha1.Property{{
+			Name:             cpv1alpha1.PropertyRegion,
+			Value:            "eu-west-1",
+			LastObservedTime: metav1.NewTime(time.Now().Add(time.Hour)),
+		}}
+		_, err = clusterProfileClient.ApisV1alpha1().ClusterProfiles(testNamespace).UpdateStatus(
+			context.TODO(), profile, metav1.UpdateOptions{},
+		)
+		gomega.Expect(errors.IsInvalid(err)).To(gomega.BeTrue())
+		gomega.Expect(err.Error()).To(gomega.ContainSubstring("status.properties[0].lastObservedTime"))
+	})
+
+	ginkgo.It("Should check property values against their type", func() {
+		profile, err := create(newClusterProfile(map[string]string{cpv1alpha1.LabelClusterManagerKey: "fleet"}))
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+		profile.Status.Properties = []cpv1alpha1.Property{
+			{Name: "example.com/gpus", Value: "8", Type: cpv1alpha1.PropertyValueTypeInteger},
+			{Name: "example.com/gpu-memory", Value: "80Gi", Type: cpv1alpha1.PropertyValueTypeQuantity},
+			{Name: "example.com/untyped", Value: "eight"},
+		}
+		profile, err = clusterProfileClient.ApisV1alpha1().ClusterProfiles(testNamespace).UpdateStatus(
+			context.TODO(), profile, metav1.UpdateOptions{},
+		)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+		profile.Status.Properties[0].Value = "eight"
+		_, err = clusterProfileClient.ApisV1alpha1().ClusterProfiles(testNamespace).UpdateStatus(
+			context.TODO(), profile, metav1.UpdateOptions{},
+		)
+		gomega.Expect(errors.IsInvalid(err)).To(gomega.BeTrue())
+		gomega.Expect(err.Error()).To(gomega.ContainSubstring("value must be a 64-bit integer"))
+
+		// Integers are bounded like the int64 that IntegerProperty returns.
+		profile.Status.Properties[0].Value = "9223372036854775808"
+		_, err = clusterProfileClient.ApisV1alpha1().ClusterProfiles(testNamespace).UpdateStatus(
+			context.TODO(), profile, metav1.UpdateOptions{},
+		)
+		gomega.Expect(errors.IsInvalid(err)).To(gomega.BeTrue())
+	})
+})
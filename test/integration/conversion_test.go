@@ -0,0 +1,80 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+
+	cpv1alpha1 "sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+	cpv1alpha2 "sigs.k8s.io/cluster-inventory-api/apis/v1alpha2"
+)
+
+var _ = ginkgo.Describe("Conversion webhook test", func() {
+	var name string
+
+	ginkgo.BeforeEach(func() {
+		name = fmt.Sprintf("conversion-%s", rand.String(5))
+	})
+
+	ginkgo.It("Should serve a v1alpha2 ClusterProfile as v1alpha1 and back", func() {
+		profile, err := clusterProfileClient.ApisV1alpha2().ClusterProfiles(testNamespace).Create(
+			context.TODO(),
+			&cpv1alpha2.ClusterProfile{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   name,
+					Labels: map[string]string{cpv1alpha1.LabelClusterManagerKey: "fleet"},
+				},
+				Spec: cpv1alpha2.ClusterProfileSpec{
+					ClusterManager: cpv1alpha2.ClusterManager{Name: "fleet"},
+				},
+			},
+			metav1.CreateOptions{},
+		)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+		profile.Status.AccessProviders = []cpv1alpha2.AccessProvider{{
+			Name:   "secretreader",
+			Labels: map[string]string{"network": "private"},
+		}}
+		_, err = clusterProfileClient.ApisV1alpha2().ClusterProfiles(testNamespace).UpdateStatus(
+			context.TODO(), profile, metav1.UpdateOptions{},
+		)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+		stored, err := clusterProfileClient.ApisV1alpha1().ClusterProfiles(testNamespace).Get(
+			context.TODO(), name, metav1.GetOptions{},
+		)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(stored.Status.AccessProviders).To(gomega.HaveLen(1))
+		gomega.Expect(stored.Status.AccessProviders[0].Name).To(gomega.Equal("secretreader"))
+
+		profile, err = clusterProfileClient.ApisV1alpha2().ClusterProfiles(testNamespace).Get(
+			context.TODO(), name, metav1.GetOptions{},
+		)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(profile.Annotations).ToNot(gomega.HaveKey(cpv1alpha2.ConversionDataAnnotation))
+		gomega.Expect(profile.Status.AccessProviders[0].Labels).To(
+			gomega.Equal(map[string]string{"network": "private"}))
+	})
+})
@@ -18,20 +18,37 @@ package integration
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/rand"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
 
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
 	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	cpv1alpha1 "sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+	cpv1alpha2 "sigs.k8s.io/cluster-inventory-api/apis/v1alpha2"
 	cpclientset "sigs.k8s.io/cluster-inventory-api/client/clientset/versioned"
+	cpwebhook "sigs.k8s.io/cluster-inventory-api/pkg/webhook"
 )
 
 var testEnv *envtest.Environment
@@ -39,6 +56,8 @@ var testNamespace string
 var cfg *rest.Config
 var kubernetesClient kubernetes.Interface
 var clusterProfileClient cpclientset.Interface
+var stopWebhook context.CancelFunc
+var legacyClusterProfile *cpv1alpha1.ClusterProfile
 
 func TestIntegration(t *testing.T) {
 	gomega.RegisterFailHandler(ginkgo.Fail)
@@ -48,11 +67,30 @@ func TestIntegration(t *testing.T) {
 var _ = ginkgo.BeforeSuite(func() {
 	ginkgo.By("bootstrapping test environment")
 
+	scheme := runtime.NewScheme()
+	gomega.Expect(cpv1alpha1.AddToScheme(scheme)).To(gomega.Succeed())
+	gomega.Expect(cpv1alpha2.AddToScheme(scheme)).To(gomega.Succeed())
+
+	crdOptions := envtest.CRDInstallOptions{
+		Paths:              []string{filepath.Join("../../", "config", "crd", "bases")},
+		ErrorIfPathMissing: true,
+	}
+	gomega.Expect(envtest.ReadCRDFiles(&crdOptions)).To(gomega.Succeed())
+	// The CRDs ship v1alpha2 unserved because they carry no conversion config.
+	// envtest runs the conversion webhook, so serve every version here.
+	for _, crd := range crdOptions.CRDs {
+		for i := range crd.Spec.Versions {
+			crd.Spec.Versions[i].Served = true
+		}
+	}
+
 	// start a kube-apiserver
 	testEnv = &envtest.Environment{
-		ErrorIfCRDPathMissing: true,
-		CRDDirectoryPaths: []string{
-			filepath.Join("../../", "config", "crd", "bases"),
+		// The scheme makes envtest point the CRDs of convertible types at the conversion webhook.
+		Scheme: scheme,
+		CRDs:   crdOptions.CRDs,
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			Paths: []string{filepath.Join("../../", "config", "webhook")},
 		},
 	}
 
@@ -66,6 +104,8 @@ var _ = ginkgo.BeforeSuite(func() {
 	kubernetesClient, err = kubernetes.NewForConfig(cfg)
 	gomega.Expect(err).NotTo(gomega.HaveOccurred())
 
+	startWebhookServer(scheme)
+
 	testNamespace = "cluster-profile-api-test-" + rand.String(5)
 	_, err = kubernetesClient.CoreV1().Namespaces().
 		Create(context.TODO(), &corev1.Namespace{
@@ -74,11 +114,34 @@ var _ = ginkgo.BeforeSuite(func() {
 			},
 		}, metav1.CreateOptions{})
 	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+	// Created before the policies, like ClusterProfiles stored before the
+	// cluster manager label was required.
+	legacyClusterProfile, err = clusterProfileClient.ApisV1alpha1().ClusterProfiles(testNamespace).Create(
+		context.TODO(),
+		&cpv1alpha1.ClusterProfile{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "legacy-" + rand.String(5),
+				Finalizers: []string{"example.com/cleanup"},
+			},
+			Spec: cpv1alpha1.ClusterProfileSpec{
+				ClusterManager: cpv1alpha1.ClusterManager{Name: "fleet"},
+			},
+		},
+		metav1.CreateOptions{},
+	)
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+	installAdmissionPolicies()
 })
 
 var _ = ginkgo.AfterSuite(func() {
 	ginkgo.By("tearing down the test environment")
 
+	if stopWebhook != nil {
+		stopWebhook()
+	}
+
 	// Skip if client wasn't instantiated
 	if kubernetesClient != nil {
 		err := kubernetesClient.CoreV1().Namespaces().
@@ -89,3 +152,67 @@ var _ = ginkgo.AfterSuite(func() {
 		gomega.Expect(err).ToNot(gomega.HaveOccurred())
 	}
 })
+
+// startWebhookServer serves the validating and conversion webhooks installed by
+// envtest and waits until the server accepts connections.
+func startWebhookServer(scheme *runtime.Scheme) {
+	opts := &testEnv.WebhookInstallOptions
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme: scheme,
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Host:    opts.LocalServingHost,
+			Port:    opts.LocalServingPort,
+			CertDir: opts.LocalServingCertDir,
+		}),
+		Metrics: metricsserver.Options{BindAddress: "0"},
+	})
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	gomega.Expect(cpwebhook.SetupWithManager(mgr)).To(gomega.Succeed())
+
+	var ctx context.Context
+	ctx, stopWebhook = context.WithCancel(context.Background())
+	go func() {
+		defer ginkgo.GinkgoRecover()
+		gomega.Expect(mgr.Start(ctx)).To(gomega.Succeed())
+	}()
+
+	addr := net.JoinHostPort(opts.LocalServingHost, fmt.Sprint(opts.LocalServingPort))
+	gomega.Eventually(func() error {
+		conn, err := tls.DialWithDialer(&net.Dialer{Timeout: time.Second}, "tcp", addr,
+			&tls.Config{InsecureSkipVerify: true}) //nolint:gosec // test server with a self-signed certificate
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}).Should(gomega.Succeed())
+}
+
+// installAdmissionPolicies creates the ValidatingAdmissionPolicies and their
+// bindings in config/policy.
+func installAdmissionPolicies() {
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+	files, err := filepath.Glob(filepath.Join("../../", "config", "policy", "*.yaml"))
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	for _, file := range files {
+		if filepath.Base(file) == "kustomization.yaml" {
+			continue
+		}
+		f, err := os.Open(file)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		decoder := utilyaml.NewYAMLOrJSONDecoder(f, 4096)
+		for {
+			obj := &unstructured.Unstructured{}
+			err := decoder.Decode(&obj.Object)
+			if err == io.EOF {
+				break
+			}
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gvr, _ := meta.UnsafeGuessKindToResource(obj.GroupVersionKind())
+			_, err = dynamicClient.Resource(gvr).Create(context.TODO(), obj, metav1.CreateOptions{})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		}
+		gomega.Expect(f.Close()).To(gomega.Succeed())
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/rand"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+
+	cpv1alpha1 "sigs.k8s.io/cluster-inventory-api/apis/v1alpha1"
+)
+
+var _ = ginkgo.Describe("Validating webhook test", func() {
+	var name string
+
+	ginkgo.BeforeEach(func() {
+		name = fmt.Sprintf("webhook-%s", rand.String(5))
+	})
+
+	newClusterProfile := func(label string) *cpv1alpha1.ClusterProfile {
+		return &cpv1alpha1.ClusterProfile{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{cpv1alpha1.LabelClusterManagerKey: label},
+			},
+			Spec: cpv1alpha1.ClusterProfileSpec{
+				ClusterManager: cpv1alpha1.ClusterManager{Name: "fleet"},
+			},
+		}
+	}
+
+	ginkgo.It("Should reject a ClusterProfile whose cluster manager label does not match", func() {
+		_, err := clusterProfileClient.ApisV1alpha1().ClusterProfiles(testNamespace).Create(
+			context.TODO(), newClusterProfile("other"), metav1.CreateOptions{},
+		)
+		gomega.Expect(errors.IsInvalid(err)).To(gomega.BeTrue())
+		gomega.Expect(err.Error()).To(gomega.ContainSubstring("spec.clusterManager.name"))
+	})
+
+	ginkgo.It("Should reject status updates with invalid access providers", func() {
+		profile, err := clusterProfileClient.ApisV1alpha1().ClusterProfiles(testNamespace).Create(
+			context.TODO(), newClusterProfile("fleet"), metav1.CreateOptions{},
+		)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+		profile.Status.AccessProviders = []cpv1alpha1.AccessProvider{{
+			Name: "exec",
+			Cluster: clientcmdv1.Cluster{
+				Server: "https://spoke",
+				Extensions: []clientcmdv1.NamedExtension{{
+					Name:      "clusterprofiles.multicluster.x-k8s.io/exec/additional-args",
+					Extension: runtime.RawExtension{Raw: []byte(`{"not":"a list"}`)},
+				}},
+			},
+		}}
+		_, err = clusterProfileClient.ApisV1alpha1().ClusterProfiles(testNamespace).UpdateStatus(
+			context.TODO(), profile, metav1.UpdateOptions{},
+		)
+		gomega.Expect(errors.IsInvalid(err)).To(gomega.BeTrue())
+		gomega.Expect(err.Error()).To(gomega.ContainSubstring("status.accessProviders[0].cluster.extensions[0].extension"))
+	})
+
+	ginkgo.It("Should reject a PlacementDecision with duplicate references", func() {
+		placementDecision := &cpv1alpha1.PlacementDecision{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Decisions: []cpv1alpha1.ClusterDecision{
+				{ClusterProfileRef: cpv1alpha1.ClusterProfileReference{Name: "spoke-1"}},
+				{ClusterProfileRef: cpv1alpha1.ClusterProfileReference{Name: "spoke-1", Namespace: testNamespace}},
+			},
+		}
+		_, err := clusterProfileClient.ApisV1alpha1().PlacementDecisions(testNamespace).Create(
+			context.TODO(), placementDecision, metav1.CreateOptions{},
+		)
+		gomega.Expect(errors.IsInvalid(err)).To(gomega.BeTrue())
+		gomega.Expect(err.Error()).To(gomega.ContainSubstring("decisions[1].clusterProfileRef"))
+	})
+})